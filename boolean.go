@@ -0,0 +1,269 @@
+package polygon
+
+import "fmt"
+
+// boolOp identifies which Boolean combination clipShape performs.
+type boolOp int
+
+const (
+	opUnion boolOp = iota
+	opIntersection
+	opDifference
+	opSymDiff
+)
+
+// midpoint returns the point halfway between a and b.
+func midpoint(a, b Point) Point {
+	return Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+}
+
+// keepEdge decides, for an edge of one of the two operand polygons,
+// whether it survives in the result of op, and whether it must be
+// reversed to keep the result's winding consistent. subject is true for
+// an edge belonging to the first (p1) operand of clipShape, inside
+// indicates whether the edge's midpoint falls within the other operand.
+func keepEdge(op boolOp, subject, inside bool) (keep, reverse bool) {
+	switch op {
+	case opUnion:
+		return !inside, false
+	case opIntersection:
+		return inside, false
+	case opDifference:
+		if subject {
+			return !inside, false
+		}
+		return inside, true
+	case opSymDiff:
+		return true, inside
+	}
+	return false, false
+}
+
+// asHole returns a duplicate of s with its perimeter wound so it acts
+// as a hole (clockwise).
+func asHole(s *Shape) *Shape {
+	dup := s.Duplicate()
+	if !dup.Hole {
+		holed := &Shapes{P: []*Shape{dup}}
+		holed.Invert(0)
+	}
+	return dup
+}
+
+// disjointClip resolves clipShape for the case where p1 and p2 share no
+// crossing point, so they are either nested or entirely separate.
+func disjointClip(p1, p2 *Shape, aInB, bInA bool, op boolOp) *Shapes {
+	switch op {
+	case opUnion:
+		if aInB {
+			return (&Shapes{}).Include(p2.Duplicate())
+		}
+		if bInA {
+			return (&Shapes{}).Include(p1.Duplicate())
+		}
+		return (&Shapes{}).Include(p1.Duplicate(), p2.Duplicate())
+	case opIntersection:
+		if aInB {
+			return (&Shapes{}).Include(p1.Duplicate())
+		}
+		if bInA {
+			return (&Shapes{}).Include(p2.Duplicate())
+		}
+		return &Shapes{}
+	case opDifference:
+		if aInB {
+			return &Shapes{}
+		}
+		if bInA {
+			return (&Shapes{}).Include(p1.Duplicate(), asHole(p2))
+		}
+		return (&Shapes{}).Include(p1.Duplicate())
+	case opSymDiff:
+		if aInB {
+			return (&Shapes{}).Include(p2.Duplicate(), asHole(p1))
+		}
+		if bInA {
+			return (&Shapes{}).Include(p1.Duplicate(), asHole(p2))
+		}
+		return (&Shapes{}).Include(p1.Duplicate(), p2.Duplicate())
+	}
+	return &Shapes{}
+}
+
+// clipShape performs the Boolean operation op on the pair of simple
+// polygons p1, p2. It reuses the same crossings() insertion pass as
+// Union to obtain dissolved rings with shared vertices at every
+// crossing, classifies each resulting edge by whether its midpoint
+// falls inside the other polygon (via prunedInside), keeps or discards
+// and re-orients edges according to op, and stitches what remains back
+// into closed rings via Rationalize.
+func clipShape(p1, p2 *Shape, op boolOp) (*Shapes, error) {
+	if p1 == nil || p2 == nil {
+		return nil, fmt.Errorf("clipShape requires two non-nil shapes")
+	}
+	hits, n1, n2 := crossings(p1, p2)
+	aInB, bInA := insider(hits, n1, n2)
+	if len(hits) == 0 {
+		return disjointClip(p1, p2, aInB, bInA, op), nil
+	}
+
+	type seg struct{ a, b Point }
+	var segs []seg
+	for i := 0; i < len(n1.PS); i++ {
+		a, b := n1.PS[i], n1.PS[(i+1)%len(n1.PS)]
+		inside := midpoint(a, b).prunedInside(n2, nil)
+		if keep, reverse := keepEdge(op, true, inside); keep {
+			if reverse {
+				a, b = b, a
+			}
+			segs = append(segs, seg{a, b})
+		}
+	}
+	for i := 0; i < len(n2.PS); i++ {
+		a, b := n2.PS[i], n2.PS[(i+1)%len(n2.PS)]
+		inside := midpoint(a, b).prunedInside(n1, nil)
+		if keep, reverse := keepEdge(op, false, inside); keep {
+			if reverse {
+				a, b = b, a
+			}
+			segs = append(segs, seg{a, b})
+		}
+	}
+
+	avail := make(map[Point][]Point)
+	for _, s := range segs {
+		avail[s.a] = append(avail[s.a], s.b)
+	}
+	result := &Shapes{}
+	seen := make(map[Point]bool)
+	for _, s := range segs {
+		start := s.a
+		if seen[start] {
+			continue
+		}
+		var pts []Point
+		cur := start
+		for {
+			lst := avail[cur]
+			if len(lst) == 0 {
+				break
+			}
+			avail[cur] = lst[1:]
+			pts = append(pts, cur)
+			seen[cur] = true
+			cur = lst[0]
+			if cur == start {
+				break
+			}
+		}
+		if len(pts) < 3 {
+			continue
+		}
+		if poly, err := Rationalize(pts); err == nil {
+			result = result.Include(poly)
+		}
+	}
+	return result, nil
+}
+
+// anyHole reports whether any shape of p is a hole.
+func anyHole(p *Shapes) bool {
+	if p == nil {
+		return false
+	}
+	for _, s := range p.P {
+		if s.Hole {
+			return true
+		}
+	}
+	return false
+}
+
+// Intersect returns the region common to both p and other. Neither
+// operand may contain a hole: clipShape's edge classification only
+// considers each shape as a solid outline, so a hole would silently be
+// treated as a second outer shape rather than subtracted, giving the
+// wrong region. Use Clip (clip.go), whose scanbeam engine does honor
+// holes, if either operand has one. (The name Intersection is taken by
+// that method.)
+func (p *Shapes) Intersect(other *Shapes) (*Shapes, error) {
+	result := &Shapes{}
+	if p == nil || other == nil {
+		return result, nil
+	}
+	if anyHole(p) || anyHole(other) {
+		return nil, fmt.Errorf("Intersect does not support hole-bearing operands; use Clip instead")
+	}
+	for _, a := range p.P {
+		for _, b := range other.P {
+			pieces, err := clipShape(a, b, opIntersection)
+			if err != nil || pieces == nil {
+				continue
+			}
+			result = result.Include(pieces.P...)
+		}
+	}
+	result.Union()
+	return result, nil
+}
+
+// Difference returns the region of p with other removed. Neither
+// operand may contain a hole; see Intersect for why.
+func (p *Shapes) Difference(other *Shapes) (*Shapes, error) {
+	result := &Shapes{}
+	if p == nil {
+		return result, nil
+	}
+	if anyHole(p) || anyHole(other) {
+		return nil, fmt.Errorf("Difference does not support hole-bearing operands; use Clip instead")
+	}
+	for _, a := range p.P {
+		cur := []*Shape{a.Duplicate()}
+		if other != nil {
+			for _, b := range other.P {
+				if len(cur) == 0 {
+					continue
+				}
+				var next []*Shape
+				for _, c := range cur {
+					pieces, err := clipShape(c, b, opDifference)
+					if err != nil || pieces == nil {
+						continue
+					}
+					next = append(next, pieces.P...)
+				}
+				cur = next
+			}
+		}
+		result = result.Include(cur...)
+	}
+	result.Union()
+	return result, nil
+}
+
+// SymDiff returns the region covered by exactly one of p and other.
+// Neither operand may contain a hole; see Intersect for why.
+func (p *Shapes) SymDiff(other *Shapes) (*Shapes, error) {
+	if anyHole(p) || anyHole(other) {
+		return nil, fmt.Errorf("SymDiff does not support hole-bearing operands; use Clip instead")
+	}
+	pMinusOther, err := p.Difference(other)
+	if err != nil {
+		return nil, err
+	}
+	otherMinusP, err := other.Difference(p)
+	if err != nil {
+		return nil, err
+	}
+	result := &Shapes{}
+	result = result.Include(pMinusOther.P...)
+	result = result.Include(otherMinusP.P...)
+	result.Reorder()
+	return result, nil
+}
+
+// SymmetricDifference is a synonym for SymDiff, named to match
+// Difference/Intersection elsewhere in this API.
+func (p *Shapes) SymmetricDifference(other *Shapes) (*Shapes, error) {
+	return p.SymDiff(other)
+}
@@ -0,0 +1,132 @@
+package polygon
+
+import "testing"
+
+func square(ll, tr Point) []Point {
+	return []Point{ll, {tr.X, ll.Y}, tr, {ll.X, tr.Y}}
+}
+
+func checkPoints(t *testing.T, label string, got []Point, want []Point) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d points %v, want %d points %v", label, len(got), got, len(want), want)
+	}
+	for i, g := range got {
+		if w := want[i]; g != w {
+			t.Errorf("%s: point[%d] got=%v, want=%v", label, i, g, w)
+		}
+	}
+}
+
+func TestBooleanDisjoint(t *testing.T) {
+	var a, b *Shapes
+	a = a.Builder(square(Point{0, 0}, Point{1, 1})...)
+	b = b.Builder(square(Point{2, 2}, Point{3, 3})...)
+
+	if got, err := a.Intersect(b); err != nil {
+		t.Errorf("disjoint Intersect: %v", err)
+	} else if len(got.P) != 0 {
+		t.Errorf("disjoint Intersect: got %d shapes, want 0", len(got.P))
+	}
+	if got, err := a.Difference(b); err != nil {
+		t.Errorf("disjoint Difference: %v", err)
+	} else if len(got.P) != 1 {
+		t.Errorf("disjoint Difference: got %d shapes, want 1", len(got.P))
+	} else {
+		checkPoints(t, "disjoint Difference", got.P[0].PS, square(Point{0, 0}, Point{1, 1}))
+	}
+	if got, err := a.SymDiff(b); err != nil {
+		t.Errorf("disjoint SymDiff: %v", err)
+	} else if len(got.P) != 2 {
+		t.Errorf("disjoint SymDiff: got %d shapes, want 2", len(got.P))
+	}
+}
+
+func TestBooleanNested(t *testing.T) {
+	var a, b *Shapes
+	a = a.Builder(square(Point{0, 0}, Point{4, 4})...)
+	b = b.Builder(square(Point{1, 1}, Point{2, 2})...)
+
+	got, err := a.Intersect(b)
+	if err != nil {
+		t.Fatalf("nested Intersect: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("nested Intersect: got %d shapes, want 1", len(got.P))
+	} else {
+		checkPoints(t, "nested Intersect", got.P[0].PS, square(Point{1, 1}, Point{2, 2}))
+	}
+	diff, err := a.Difference(b)
+	if err != nil {
+		t.Fatalf("nested Difference: %v", err)
+	}
+	if len(diff.P) != 2 {
+		t.Fatalf("nested Difference: got %d shapes, want 2 (outer + hole)", len(diff.P))
+	}
+	if diff.P[0].Hole {
+		t.Errorf("nested Difference: first shape should not be a hole")
+	}
+	if !diff.P[1].Hole {
+		t.Errorf("nested Difference: second shape should be a hole")
+	}
+	sym, err := a.SymDiff(b)
+	if err != nil {
+		t.Fatalf("nested SymDiff: %v", err)
+	}
+	if len(sym.P) != 2 || !sym.P[1].Hole {
+		t.Fatalf("nested SymDiff: expecting outer shape plus hole, got %#v", sym.P)
+	}
+}
+
+func TestSymmetricDifferenceMatchesSymDiff(t *testing.T) {
+	var a, b *Shapes
+	a = a.Builder(square(Point{0, 0}, Point{2, 2})...)
+	b = b.Builder(square(Point{1, 1}, Point{3, 3})...)
+
+	got, err := a.SymmetricDifference(b)
+	if err != nil {
+		t.Fatalf("SymmetricDifference: %v", err)
+	}
+	want, err := a.SymDiff(b)
+	if err != nil {
+		t.Fatalf("SymDiff: %v", err)
+	}
+	if len(got.P) != len(want.P) {
+		t.Fatalf("SymmetricDifference: got %d shapes, want %d", len(got.P), len(want.P))
+	}
+	for i := range want.P {
+		checkPoints(t, "SymmetricDifference", got.P[i].PS, want.P[i].PS)
+	}
+}
+
+func TestBooleanOverlap(t *testing.T) {
+	var a, b *Shapes
+	a = a.Builder(square(Point{0, 0}, Point{2, 2})...)
+	b = b.Builder(square(Point{1, 1}, Point{3, 3})...)
+
+	got, err := a.Intersect(b)
+	if err != nil {
+		t.Fatalf("overlap Intersect: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("overlap Intersect: got %d shapes, want 1: %#v", len(got.P), got.P)
+	}
+	checkPoints(t, "overlap Intersect", got.P[0].PS, square(Point{1, 1}, Point{2, 2}))
+}
+
+func TestBooleanRejectsHoleOperands(t *testing.T) {
+	var a, b *Shapes
+	a = a.Builder(square(Point{0, 0}, Point{10, 10})...)
+	a = a.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	b = b.Builder(square(Point{0, 0}, Point{10, 10})...)
+
+	if _, err := a.Intersect(b); err == nil {
+		t.Errorf("expected Intersect to reject a hole-bearing operand")
+	}
+	if _, err := a.Difference(b); err == nil {
+		t.Errorf("expected Difference to reject a hole-bearing operand")
+	}
+	if _, err := a.SymDiff(b); err == nil {
+		t.Errorf("expected SymDiff to reject a hole-bearing operand")
+	}
+}
@@ -0,0 +1,428 @@
+package polygon
+
+import (
+	"math"
+	"sort"
+)
+
+// FillRule selects how a winding number is interpreted as "filled" by
+// Clip: NonZero treats any non-zero winding as filled (the usual
+// convention, and the one the rest of this package assumes when it
+// classifies a ring's Hole flag from its orientation), EvenOdd treats
+// odd windings as filled regardless of sign.
+type FillRule int
+
+const (
+	FillNonZero FillRule = iota
+	FillEvenOdd
+)
+
+func (f FillRule) filled(w int) bool {
+	if f == FillEvenOdd {
+		return w%2 != 0
+	}
+	return w != 0
+}
+
+// Op selects the Boolean combination Clip computes from the subject
+// (p) and clip (q) windings at a point.
+type Op int
+
+const (
+	OpUnion Op = iota
+	OpIntersection
+	OpDifference
+	OpXOR
+)
+
+func (op Op) combine(inSubj, inClip bool) bool {
+	switch op {
+	case OpIntersection:
+		return inSubj && inClip
+	case OpDifference:
+		return inSubj && !inClip
+	case OpXOR:
+		return inSubj != inClip
+	default:
+		return inSubj || inClip
+	}
+}
+
+// clipEdge is a single non-horizontal polygon edge prepared for the
+// Clip sweep: lo and hi are its endpoints ordered by increasing Y,
+// subj marks whether it came from the subject or the clip Shapes, and
+// wind is its winding contribution (+1 if the edge ran from lo to hi
+// in the source ring, -1 if it ran from hi to lo).
+type clipEdge struct {
+	lo, hi Point
+	subj   bool
+	wind   int
+}
+
+// xAt returns the edge's X coordinate at height y, which must fall
+// within [lo.Y, hi.Y].
+func (e *clipEdge) xAt(y float64) float64 {
+	if e.hi.Y == e.lo.Y {
+		return e.lo.X
+	}
+	t := (y - e.lo.Y) / (e.hi.Y - e.lo.Y)
+	return e.lo.X + t*(e.hi.X-e.lo.X)
+}
+
+// buildClipEdges extracts the non-horizontal edges of every shape in
+// p as clipEdges tagged subj. Horizontal edges contribute nothing to
+// a vertical sweep's winding count, so they're dropped.
+func buildClipEdges(p *Shapes, subj bool) []*clipEdge {
+	var edges []*clipEdge
+	if p == nil {
+		return edges
+	}
+	for _, s := range p.P {
+		n := len(s.PS)
+		for i := 0; i < n; i++ {
+			a, b := s.PS[i], s.PS[(i+1)%n]
+			if a.Y == b.Y {
+				continue
+			}
+			e := &clipEdge{subj: subj, wind: 1}
+			if a.Y < b.Y {
+				e.lo, e.hi = a, b
+			} else {
+				e.lo, e.hi = b, a
+				e.wind = -1
+			}
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// scanbeamYs returns the distinct Y coordinates (within Zeroish) of
+// every edge endpoint, ascending: the scanbeam table that Clip sweeps
+// band by band.
+func scanbeamYs(edges []*clipEdge) []float64 {
+	var ys []float64
+	add := func(y float64) {
+		for _, s := range ys {
+			if math.Abs(s-y) < Zeroish {
+				return
+			}
+		}
+		ys = append(ys, y)
+	}
+	for _, e := range edges {
+		add(e.lo.Y)
+		add(e.hi.Y)
+	}
+	sort.Float64s(ys)
+	return ys
+}
+
+// firstCrossing finds the lowest Y strictly inside (y0, y1) at which
+// any two of active (straight, and each spanning the whole band)
+// swap left-right order, if any. Since every edge is linear in Y
+// across the band, two edges cross at most once there, so this is a
+// single linear solve per pair.
+func firstCrossing(active []*clipEdge, y0, y1 float64) (float64, bool) {
+	best, found := y1, false
+	for i := 0; i < len(active); i++ {
+		for j := i + 1; j < len(active); j++ {
+			a, b := active[i], active[j]
+			d0 := a.xAt(y0) - b.xAt(y0)
+			d1 := a.xAt(y1) - b.xAt(y1)
+			if d0*d1 >= 0 {
+				continue // same sign (or touching only at an end): no interior crossing
+			}
+			y := y0 + d0/(d0-d1)*(y1-y0)
+			if y > y0+Zeroish && y < y1-Zeroish && y < best {
+				best, found = y, true
+			}
+		}
+	}
+	return best, found
+}
+
+// clipSeg is a single directed boundary segment emitted by clipBand,
+// later stitched end to end (by exact shared endpoints) into closed
+// contours.
+type clipSeg struct{ a, b Point }
+
+// snapQuantum is finer than Zeroish, the tolerance the rest of this
+// package treats two points as equal under. snap rounds a point's
+// coordinates to that grid so that the same geometric vertex, reached
+// via two different interpolations (e.g. the same scanbeam-boundary
+// point computed once as one edge's xAt(y) and once as its
+// neighbour's), always comes out bit-identical - which stitchSegs
+// needs, since it chains segments by exact endpoint equality.
+const snapQuantum = 1e-7
+
+func snap(p Point) Point {
+	return Point{X: math.Round(p.X/snapQuantum) * snapQuantum, Y: math.Round(p.Y/snapQuantum) * snapQuantum}
+}
+
+// addSeg appends a-to-b to segs (after snapping both to the shared
+// rounding grid), dropping degenerate zero-length segments (which
+// arise from a fully collapsed band cut).
+func addSeg(segs []clipSeg, a, b Point) []clipSeg {
+	a, b = snap(a), snap(b)
+	if a == b {
+		return segs
+	}
+	return append(segs, clipSeg{a, b})
+}
+
+// ival is a closed sub-interval of a horizontal cut line, [lo, hi].
+type ival struct{ lo, hi float64 }
+
+// cutLevel accumulates the horizontal cuts seen at one Y value: below
+// holds cuts contributed as the top of a band ending at y (so the
+// band's filled interval lies below y), above holds cuts contributed
+// as the bottom of a band starting at y (filled interval lies above
+// y). Where the two disagree, that sub-range is a real edge of the
+// result (see emitCuts); where they agree, it's purely an artifact of
+// slicing the sweep into bands.
+type cutLevel struct {
+	y            float64
+	below, above []ival
+}
+
+// cutTable groups cuts by Y (within Zeroish), found by linear scan:
+// the same tolerance-based approach scanbeamYs uses, and fine at the
+// same scale since there's one level per scanbeam line.
+type cutTable struct{ levels []*cutLevel }
+
+func (t *cutTable) at(y float64) *cutLevel {
+	for _, l := range t.levels {
+		if math.Abs(l.y-y) < Zeroish {
+			return l
+		}
+	}
+	l := &cutLevel{y: y}
+	t.levels = append(t.levels, l)
+	return l
+}
+
+// clipBand sweeps active (every edge spanning [y0, y1]) left to
+// right by X, accumulating separate subject and clip winding numbers.
+// Wherever op.combine of the two fill tests toggles, a filled
+// interval opens or closes, contributing the two crossing edges' own
+// slanted paths across the band directly to segs (always real
+// boundary), and the horizontal cuts at y0 and y1 - artifacts of
+// slicing the sweep into bands, not necessarily real boundary - to
+// cuts, for emitCuts to resolve once every band bordering that Y has
+// been swept. If any pair of active edges crosses strictly inside the
+// band, their left-right order isn't stable across it, so the band is
+// first split at the lowest such crossing and each half is swept
+// independently (the split itself adds a cutLevel at the crossing
+// height, which cancels out since both halves agree there).
+func clipBand(y0, y1 float64, active []*clipEdge, fill FillRule, op Op, segs []clipSeg, cuts *cutTable) []clipSeg {
+	if y1-y0 < Zeroish {
+		return segs
+	}
+	if cy, ok := firstCrossing(active, y0, y1); ok {
+		segs = clipBand(y0, cy, active, fill, op, segs, cuts)
+		return clipBand(cy, y1, active, fill, op, segs, cuts)
+	}
+	order := append([]*clipEdge{}, active...)
+	mid := (y0 + y1) / 2
+	sort.Slice(order, func(i, j int) bool {
+		return order[i].xAt(mid) < order[j].xAt(mid)
+	})
+	subjWind, clipWind := 0, 0
+	wasIn := op.combine(fill.filled(subjWind), fill.filled(clipWind))
+	open := false
+	var openLo, openHi Point
+	for _, e := range order {
+		if e.subj {
+			subjWind += e.wind
+		} else {
+			clipWind += e.wind
+		}
+		isIn := op.combine(fill.filled(subjWind), fill.filled(clipWind))
+		if isIn == wasIn {
+			continue
+		}
+		lo, hi := Point{e.xAt(y0), y0}, Point{e.xAt(y1), y1}
+		switch {
+		case isIn && !open:
+			openLo, openHi, open = lo, hi, true
+		case !isIn && open:
+			segs = addSeg(segs, openHi, openLo) // left, CCW travels top to bottom
+			segs = addSeg(segs, lo, hi)         // right, CCW travels bottom to top
+			if x0, x1 := openLo.X, lo.X; x1-x0 > Zeroish {
+				level := cuts.at(y0)
+				level.above = append(level.above, ival{x0, x1})
+			}
+			if x0, x1 := openHi.X, hi.X; x1-x0 > Zeroish {
+				level := cuts.at(y1)
+				level.below = append(level.below, ival{x0, x1})
+			}
+			open = false
+		}
+		wasIn = isIn
+	}
+	return segs
+}
+
+// emitCuts resolves every accumulated cutLevel into real boundary
+// segments: it sweeps each level's below and above intervals together
+// by X, and wherever exactly one of them covers the sub-range (not
+// both, and not neither - those cancel as pure band-slicing
+// artifacts), emits that sub-range as a segment, oriented left to
+// right if only above covers it (the bottom edge of a shape with
+// nothing below, CCW travels left to right there) or right to left if
+// only below covers it (the top edge, CCW travels right to left).
+func emitCuts(cuts *cutTable, segs []clipSeg) []clipSeg {
+	for _, level := range cuts.levels {
+		type evt struct {
+			x              float64
+			dBelow, dAbove int
+		}
+		var evts []evt
+		for _, iv := range level.below {
+			evts = append(evts, evt{iv.lo, 1, 0}, evt{iv.hi, -1, 0})
+		}
+		for _, iv := range level.above {
+			evts = append(evts, evt{iv.lo, 0, 1}, evt{iv.hi, 0, -1})
+		}
+		if len(evts) == 0 {
+			continue
+		}
+		sort.Slice(evts, func(i, j int) bool { return evts[i].x < evts[j].x })
+		var xs []float64
+		var dBelow, dAbove []int
+		for _, e := range evts {
+			if n := len(xs); n > 0 && math.Abs(xs[n-1]-e.x) < Zeroish {
+				dBelow[n-1] += e.dBelow
+				dAbove[n-1] += e.dAbove
+				continue
+			}
+			xs = append(xs, e.x)
+			dBelow = append(dBelow, e.dBelow)
+			dAbove = append(dAbove, e.dAbove)
+		}
+		countBelow, countAbove := 0, 0
+		for i := 0; i+1 < len(xs); i++ {
+			countBelow += dBelow[i]
+			countAbove += dAbove[i]
+			x0, x1 := xs[i], xs[i+1]
+			if x1-x0 < Zeroish {
+				continue
+			}
+			belowFilled, aboveFilled := countBelow > 0, countAbove > 0
+			if belowFilled == aboveFilled {
+				continue
+			}
+			if aboveFilled {
+				segs = addSeg(segs, Point{x0, level.y}, Point{x1, level.y})
+			} else {
+				segs = addSeg(segs, Point{x1, level.y}, Point{x0, level.y})
+			}
+		}
+	}
+	return segs
+}
+
+// stitchSegs chains segs end to end by exact shared endpoints into
+// closed contours, the same technique clipShape uses to reassemble
+// kept edges.
+func stitchSegs(segs []clipSeg) *Shapes {
+	avail := make(map[Point][]Point)
+	for _, s := range segs {
+		avail[s.a] = append(avail[s.a], s.b)
+	}
+	out := &Shapes{}
+	seen := make(map[Point]bool)
+	for _, s := range segs {
+		start := s.a
+		if seen[start] {
+			continue
+		}
+		var pts []Point
+		cur := start
+		for {
+			lst := avail[cur]
+			if len(lst) == 0 {
+				break
+			}
+			avail[cur] = lst[1:]
+			pts = append(pts, cur)
+			seen[cur] = true
+			cur = lst[0]
+			if cur == start {
+				break
+			}
+		}
+		if len(pts) < 3 {
+			continue
+		}
+		poly, err := Rationalize(pts)
+		if err != nil {
+			continue
+		}
+		// Scanbeam band boundaries often leave a vertex sitting
+		// exactly between two collinear neighbours (e.g. a cut
+		// point on a straight edge shared by the band above and
+		// below); dissolve prunes those back out.
+		if d, err := poly.dissolve(); err == nil {
+			poly = d
+		}
+		out = out.Include(poly)
+	}
+	return out
+}
+
+// Clip computes the Boolean combination of p (the subject) and q
+// (the clip shape) selected by op, under fill. It builds a scanbeam
+// table from every edge endpoint's Y coordinate in p and q, and for
+// each band between consecutive scanbeam lines sweeps the edges
+// spanning it left to right by X, tracking the subject and clip
+// winding numbers separately so holes (wound oppositely to their
+// parent) cancel correctly under either fill rule. Each band
+// contributes boundary segments wherever op.combine of the two fill
+// tests toggles; the horizontal cuts between bands are resolved
+// against their neighbours (emitCuts) to drop the ones that are pure
+// band-slicing artifacts, and what remains is stitched end to end
+// into closed, correctly oriented contours.
+//
+// Clip is the general engine behind this package's Boolean ops. Its
+// own method name is taken by the unrelated, in-place Union() that
+// merges p's own overlapping shapes, and Difference by the simpler,
+// hole-agnostic Shapes.Difference; callers wanting those combinations
+// from this engine call Clip directly with OpUnion or OpDifference.
+// Intersection and XOR have no such collision and are provided below.
+func (p *Shapes) Clip(q *Shapes, op Op, fill FillRule) *Shapes {
+	edges := append(buildClipEdges(p, true), buildClipEdges(q, false)...)
+	if len(edges) == 0 {
+		return &Shapes{}
+	}
+	ys := scanbeamYs(edges)
+	var segs []clipSeg
+	cuts := &cutTable{}
+	for i := 0; i+1 < len(ys); i++ {
+		y0, y1 := ys[i], ys[i+1]
+		var active []*clipEdge
+		for _, e := range edges {
+			if e.lo.Y <= y0+Zeroish && e.hi.Y >= y1-Zeroish {
+				active = append(active, e)
+			}
+		}
+		segs = clipBand(y0, y1, active, fill, op, segs, cuts)
+	}
+	segs = emitCuts(cuts, segs)
+	return stitchSegs(segs)
+}
+
+// Intersection returns the region common to p and q under fill,
+// computed by the Clip scanbeam engine (so, unlike Intersect, holes
+// in either operand are honored).
+func (p *Shapes) Intersection(q *Shapes, fill FillRule) *Shapes {
+	return p.Clip(q, OpIntersection, fill)
+}
+
+// XOR returns the region covered by exactly one of p and q under
+// fill, computed by the Clip scanbeam engine (so, unlike SymDiff,
+// holes in either operand are honored).
+func (p *Shapes) XOR(q *Shapes, fill FillRule) *Shapes {
+	return p.Clip(q, OpXOR, fill)
+}
@@ -0,0 +1,87 @@
+package polygon
+
+import "testing"
+
+func TestClipUnionOfOverlappingSquares(t *testing.T) {
+	var p, q *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{2, 2})...)
+	q = q.Builder(square(Point{1, 1}, Point{3, 3})...)
+	got := p.Clip(q, OpUnion, FillNonZero)
+	if len(got.P) != 1 {
+		t.Fatalf("expected a single merged shape, got %d: %#v", len(got.P), got.P)
+	}
+	checkPoints(t, "union", got.P[0].PS, []Point{
+		{0, 0}, {2, 0}, {2, 1}, {3, 1}, {3, 3}, {1, 3}, {1, 2}, {0, 2},
+	})
+}
+
+func TestClipIntersectionOfOverlappingSquares(t *testing.T) {
+	var p, q *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{2, 2})...)
+	q = q.Builder(square(Point{1, 1}, Point{3, 3})...)
+	got := p.Intersection(q, FillNonZero)
+	if len(got.P) != 1 {
+		t.Fatalf("expected a single shape, got %d: %#v", len(got.P), got.P)
+	}
+	checkPoints(t, "intersection", got.P[0].PS, square(Point{1, 1}, Point{2, 2}))
+}
+
+func TestClipDifferenceOfOverlappingSquares(t *testing.T) {
+	var p, q *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{2, 2})...)
+	q = q.Builder(square(Point{1, 1}, Point{3, 3})...)
+	got := p.Clip(q, OpDifference, FillNonZero)
+	if len(got.P) != 1 {
+		t.Fatalf("expected a single shape, got %d: %#v", len(got.P), got.P)
+	}
+	checkPoints(t, "difference", got.P[0].PS, []Point{
+		{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2},
+	})
+}
+
+func TestClipXORDisjointSquares(t *testing.T) {
+	var p, q *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{1, 1})...)
+	q = q.Builder(square(Point{2, 0}, Point{3, 1})...)
+	got := p.XOR(q, FillNonZero)
+	if len(got.P) != 2 {
+		t.Fatalf("expected two disjoint shapes, got %d", len(got.P))
+	}
+}
+
+// TestClipHonorsHoles checks that, unlike Intersect/Difference/
+// SymDiff, Clip's winding-based engine correctly excludes a hole in
+// the subject from the result.
+func TestClipHonorsHoles(t *testing.T) {
+	var p *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{10, 10})...)
+	p = p.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	if !p.P[1].Hole {
+		t.Fatalf("expected second shape to be a hole")
+	}
+	var q *Shapes
+	q = q.Builder(square(Point{4.5, 4.5}, Point{5.5, 5.5})...)
+
+	got := p.Intersection(q, FillNonZero)
+	if len(got.P) != 0 {
+		t.Fatalf("expected q entirely inside p's hole to intersect nothing, got %d shapes: %#v", len(got.P), got.P)
+	}
+}
+
+func TestClipEvenOddVsNonZero(t *testing.T) {
+	// Two same-winding nested squares: NonZero fills the inner
+	// region as solid (winding 2), EvenOdd treats it as a hole.
+	var p *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{10, 10})...)
+	p = p.Builder(square(Point{3, 3}, Point{7, 7})...)
+
+	nz := p.Clip(&Shapes{}, OpUnion, FillNonZero)
+	if len(nz.P) != 1 || len(nz.P[0].PS) != 4 {
+		t.Fatalf("NonZero union of nested same-winding squares should collapse to the outer square, got %#v", nz.P)
+	}
+
+	eo := p.Clip(&Shapes{}, OpUnion, FillEvenOdd)
+	if len(eo.P) != 2 {
+		t.Fatalf("EvenOdd union of nested same-winding squares should keep the inner square as a hole, got %d shapes", len(eo.P))
+	}
+}
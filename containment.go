@@ -0,0 +1,148 @@
+package polygon
+
+import "math"
+
+// Containment reports the result of a point-in-polygon query against
+// one position in a batch passed to (*Shapes).ContainsPoints.
+type Containment struct {
+	// Inside is true if the queried point fell within the shape
+	// (and was not excluded by a hole).
+	Inside bool
+	// HoleIdx is the index into Shapes.P of the innermost hole
+	// the point fell within, or -1 if the point is not inside any
+	// hole.
+	HoleIdx int
+}
+
+// pointOnSegment reports whether pt lies on the segment a->b, to
+// within Zeroish.
+func pointOnSegment(pt, a, b Point) bool {
+	dABX, dABY := b.X-a.X, b.Y-a.Y
+	dAPX, dAPY := pt.X-a.X, pt.Y-a.Y
+	cross := dABX*dAPY - dABY*dAPX
+	length := math.Hypot(dABX, dABY)
+	if length < Zeroish || math.Abs(cross)/length > Zeroish {
+		return false
+	}
+	dot := dAPX*dABX + dAPY*dABY
+	return dot >= -Zeroish2 && dot <= dABX*dABX+dABY*dABY+Zeroish2
+}
+
+// rayCastInside is a robust horizontal ray-casting point-in-polygon
+// test. For each edge (a,b) of pts, a crossing of the ray pt->(+X,
+// pt.Y) is counted iff the edge's Y-interval brackets pt.Y under the
+// half-open rule a.Y <= pt.Y < b.Y (checked in both edge directions,
+// since pts may wind either way), and the edge's X at that Y is
+// strictly greater than pt.X. The half-open interval, rather than a
+// nudge epsilon, is what keeps a ray that passes exactly through a
+// vertex from being counted twice by its two incident edges.
+//
+// A point found to lie exactly on an edge is reported as inside if
+// onEdge is true, odd/even crossing parity otherwise.
+func rayCastInside(pt Point, pts []Point, onEdge bool) bool {
+	inside := false
+	for i, a := range pts {
+		b := pts[(i+1)%len(pts)]
+		if onEdge && pointOnSegment(pt, a, b) {
+			return true
+		}
+		if (a.Y <= pt.Y && pt.Y < b.Y) || (b.Y <= pt.Y && pt.Y < a.Y) {
+			xAt := a.X + (pt.Y-a.Y)/(b.Y-a.Y)*(b.X-a.X)
+			if xAt > pt.X {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// Contains reports whether pt falls within shape i, and if so which
+// of p.P's holes - the innermost one whose bounding box contains pt
+// and that rayCastInside confirms - actually excludes it there.
+// holeIdx is -1 if pt is not inside any hole. Points exactly on an
+// edge of shape i, or of the hole reported in holeIdx, are treated as
+// inside.
+func (p *Shapes) Contains(i int, pt Point) (inside bool, holeIdx int) {
+	holeIdx = -1
+	if p == nil || i < 0 || i >= len(p.P) {
+		return false, holeIdx
+	}
+	s := p.P[i]
+	if pt.X < s.MinX || pt.X > s.MaxX || pt.Y < s.MinY || pt.Y > s.MaxY {
+		return false, holeIdx
+	}
+	if !rayCastInside(pt, s.PS, true) {
+		return false, holeIdx
+	}
+	inside = true
+	for hi, h := range p.P {
+		if !h.Hole || hi == i {
+			continue
+		}
+		if pt.X < h.MinX || pt.X > h.MaxX || pt.Y < h.MinY || pt.Y > h.MaxY {
+			continue
+		}
+		if !rayCastInside(pt, h.PS, true) {
+			continue
+		}
+		// Prefer the innermost (smallest-area bounding box) hole,
+		// in case holes are nested inside one another.
+		if holeIdx == -1 || (h.MaxX-h.MinX)*(h.MaxY-h.MinY) < (p.P[holeIdx].MaxX-p.P[holeIdx].MinX)*(p.P[holeIdx].MaxY-p.P[holeIdx].MinY) {
+			holeIdx = hi
+		}
+	}
+	if holeIdx != -1 {
+		inside = false
+	}
+	return inside, holeIdx
+}
+
+// ContainsPoints runs Contains for every point in pts against shape i,
+// reusing the same Rtree-backed hole lookup across the whole batch.
+func (p *Shapes) ContainsPoints(i int, pts []Point) []Containment {
+	out := make([]Containment, len(pts))
+	if p == nil || i < 0 || i >= len(p.P) {
+		for k := range out {
+			out[k] = Containment{HoleIdx: -1}
+		}
+		return out
+	}
+	var holeI []int
+	for hi, h := range p.P {
+		if h.Hole && hi != i {
+			holeI = append(holeI, hi)
+		}
+	}
+	holeTree := p.holeIndex(holeI)
+	s := p.P[i]
+	for k, pt := range pts {
+		out[k] = Containment{HoleIdx: -1}
+		if pt.X < s.MinX || pt.X > s.MaxX || pt.Y < s.MinY || pt.Y > s.MaxY {
+			continue
+		}
+		if !rayCastInside(pt, s.PS, true) {
+			continue
+		}
+		inside := true
+		best := -1
+		box := Box{MinX: pt.X, MinY: pt.Y, MaxX: pt.X, MaxY: pt.Y}
+		for _, hk := range holeTree.Query(box) {
+			hi := holeI[hk]
+			h := p.P[hi]
+			if pt.X < h.MinX || pt.X > h.MaxX || pt.Y < h.MinY || pt.Y > h.MaxY {
+				continue
+			}
+			if !rayCastInside(pt, h.PS, true) {
+				continue
+			}
+			if best == -1 || (h.MaxX-h.MinX)*(h.MaxY-h.MinY) < (p.P[best].MaxX-p.P[best].MinX)*(p.P[best].MaxY-p.P[best].MinY) {
+				best = hi
+			}
+		}
+		if best != -1 {
+			inside = false
+		}
+		out[k] = Containment{Inside: inside, HoleIdx: best}
+	}
+	return out
+}
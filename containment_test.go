@@ -0,0 +1,59 @@
+package polygon
+
+import "testing"
+
+func TestContainsOutsideShape(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	if inside, hole := ss.Contains(0, Point{20, 20}); inside || hole != -1 {
+		t.Errorf("Contains = %v, %d, want false, -1", inside, hole)
+	}
+}
+
+func TestContainsInsideShape(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	if inside, hole := ss.Contains(0, Point{5, 5}); !inside || hole != -1 {
+		t.Errorf("Contains = %v, %d, want true, -1", inside, hole)
+	}
+}
+
+func TestContainsOnEdgeIsInside(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	if inside, hole := ss.Contains(0, Point{0, 5}); !inside || hole != -1 {
+		t.Errorf("Contains on edge = %v, %d, want true, -1", inside, hole)
+	}
+}
+
+func TestContainsExcludesHole(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	ss = ss.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	if !ss.P[1].Hole {
+		t.Fatalf("expected second shape to be a hole")
+	}
+	if inside, hole := ss.Contains(0, Point{5, 5}); inside || hole != 1 {
+		t.Errorf("Contains inside hole = %v, %d, want false, 1", inside, hole)
+	}
+	if inside, hole := ss.Contains(0, Point{1, 1}); !inside || hole != -1 {
+		t.Errorf("Contains outside hole, inside shape = %v, %d, want true, -1", inside, hole)
+	}
+}
+
+func TestContainsPointsMatchesContains(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	ss = ss.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	pts := []Point{{5, 5}, {1, 1}, {20, 20}, {0, 5}}
+	got := ss.ContainsPoints(0, pts)
+	if len(got) != len(pts) {
+		t.Fatalf("expected %d results, got %d", len(pts), len(got))
+	}
+	for k, pt := range pts {
+		wantInside, wantHole := ss.Contains(0, pt)
+		if got[k].Inside != wantInside || got[k].HoleIdx != wantHole {
+			t.Errorf("pt %v: ContainsPoints = %+v, want {%v %d}", pt, got[k], wantInside, wantHole)
+		}
+	}
+}
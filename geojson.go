@@ -0,0 +1,131 @@
+package polygon
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ringCoords renders pts as a GeoJSON linear ring: [x, y] pairs,
+// closed by repeating the first point, the same closing convention
+// formatRing applies for WKT.
+func ringCoords(pts []Point) [][2]float64 {
+	out := make([][2]float64, len(pts)+1)
+	for i, pt := range pts {
+		out[i] = [2]float64{pt.X, pt.Y}
+	}
+	out[len(pts)] = out[0]
+	return out
+}
+
+// polyCoords renders g as a GeoJSON Polygon's "coordinates" value: the
+// outer ring followed by each hole.
+func polyCoords(g polyGroup) [][][2]float64 {
+	rings := make([][][2]float64, 1+len(g.holes))
+	rings[0] = ringCoords(g.outer.PS)
+	for i, h := range g.holes {
+		rings[1+i] = ringCoords(h.PS)
+	}
+	return rings
+}
+
+// geoJSONGeometry is the subset of the GeoJSON Geometry Object this
+// package reads and writes: just a Polygon or MultiPolygon, identified
+// by Type, with Coordinates left raw until the type is known.
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// MarshalGeoJSON renders p as a GeoJSON Polygon or MultiPolygon
+// geometry object, following the same outer/hole grouping as
+// MarshalWKT (see groupRings).
+func MarshalGeoJSON(p *Shapes) (string, error) {
+	if p == nil || len(p.P) == 0 {
+		return "", fmt.Errorf("MarshalGeoJSON requires at least one shape")
+	}
+	groups := groupRings(p)
+	if len(groups) == 0 {
+		return "", fmt.Errorf("MarshalGeoJSON: %d shapes, none of them a non-hole outer ring", len(p.P))
+	}
+	var geom struct {
+		Type        string `json:"type"`
+		Coordinates any    `json:"coordinates"`
+	}
+	if len(groups) == 1 {
+		geom.Type = "Polygon"
+		geom.Coordinates = polyCoords(groups[0])
+	} else {
+		all := make([][][][2]float64, len(groups))
+		for i, g := range groups {
+			all[i] = polyCoords(g)
+		}
+		geom.Type = "MultiPolygon"
+		geom.Coordinates = all
+	}
+	b, err := json.Marshal(geom)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ringsToShapes Rationalizes each GeoJSON ring in rings into a Shape,
+// dropping a duplicated closing point if present; the Hole flag comes
+// from each ring's own winding, per this package's usual CCW/CW
+// convention.
+func ringsToShapes(rings [][][2]float64) ([]*Shape, error) {
+	var shapes []*Shape
+	for _, ring := range rings {
+		pts := make([]Point, len(ring))
+		for i, c := range ring {
+			pts[i] = Point{X: c[0], Y: c[1]}
+		}
+		if n := len(pts); n > 1 && pts[0] == pts[n-1] {
+			pts = pts[:n-1]
+		}
+		poly, err := Rationalize(pts)
+		if err != nil {
+			return nil, err
+		}
+		shapes = append(shapes, poly)
+	}
+	return shapes, nil
+}
+
+// UnmarshalGeoJSON parses a GeoJSON Polygon or MultiPolygon geometry
+// object into Shapes, one Shape per ring (outer and hole alike, as
+// ParseWKT also does).
+func UnmarshalGeoJSON(s string) (*Shapes, error) {
+	var geom geoJSONGeometry
+	if err := json.Unmarshal([]byte(s), &geom); err != nil {
+		return nil, fmt.Errorf("malformed GeoJSON: %w", err)
+	}
+	result := &Shapes{}
+	switch geom.Type {
+	case "Polygon":
+		var rings [][][2]float64
+		if err := json.Unmarshal(geom.Coordinates, &rings); err != nil {
+			return nil, fmt.Errorf("malformed GeoJSON Polygon coordinates: %w", err)
+		}
+		shapes, err := ringsToShapes(rings)
+		if err != nil {
+			return nil, err
+		}
+		result = result.Include(shapes...)
+	case "MultiPolygon":
+		var polys [][][][2]float64
+		if err := json.Unmarshal(geom.Coordinates, &polys); err != nil {
+			return nil, fmt.Errorf("malformed GeoJSON MultiPolygon coordinates: %w", err)
+		}
+		for _, rings := range polys {
+			shapes, err := ringsToShapes(rings)
+			if err != nil {
+				return nil, err
+			}
+			result = result.Include(shapes...)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported GeoJSON geometry type %q", geom.Type)
+	}
+	return result, nil
+}
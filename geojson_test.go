@@ -0,0 +1,63 @@
+package polygon
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalGeoJSONPolygon(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	got, err := MarshalGeoJSON(ss)
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON: %v", err)
+	}
+	var geom map[string]any
+	if err := json.Unmarshal([]byte(got), &geom); err != nil {
+		t.Fatalf("MarshalGeoJSON produced invalid JSON: %v", err)
+	}
+	if geom["type"] != "Polygon" {
+		t.Errorf("type = %v, want Polygon", geom["type"])
+	}
+}
+
+func TestGeoJSONPolygonWithHoleRoundTrip(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	ss = ss.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4})
+	text, err := MarshalGeoJSON(ss)
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON: %v", err)
+	}
+	got, err := UnmarshalGeoJSON(text)
+	if err != nil {
+		t.Fatalf("UnmarshalGeoJSON(%s): %v", text, err)
+	}
+	if len(got.P) != len(ss.P) {
+		t.Fatalf("round trip: got %d shapes, want %d", len(got.P), len(ss.P))
+	}
+	for i, s := range ss.P {
+		checkPoints(t, "round trip", got.P[i].PS, s.PS)
+		if got.P[i].Hole != s.Hole {
+			t.Errorf("round trip shape %d: Hole = %v, want %v", i, got.P[i].Hole, s.Hole)
+		}
+	}
+}
+
+func TestUnmarshalGeoJSONMultiPolygon(t *testing.T) {
+	got, err := UnmarshalGeoJSON(`{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,1],[0,0]]],[[[2,2],[3,2],[3,3],[2,3],[2,2]]]]}`)
+	if err != nil {
+		t.Fatalf("UnmarshalGeoJSON: %v", err)
+	}
+	if len(got.P) != 2 {
+		t.Fatalf("got %d shapes, want 2", len(got.P))
+	}
+	checkPoints(t, "multipolygon[0]", got.P[0].PS, square(Point{0, 0}, Point{1, 1}))
+	checkPoints(t, "multipolygon[1]", got.P[1].PS, square(Point{2, 2}, Point{3, 3}))
+}
+
+func TestUnmarshalGeoJSONUnsupported(t *testing.T) {
+	if _, err := UnmarshalGeoJSON(`{"type":"Point","coordinates":[0,0]}`); err == nil {
+		t.Errorf("UnmarshalGeoJSON(Point): expected an error, got nil")
+	}
+}
@@ -0,0 +1,284 @@
+// Package predicates implements Shewchuk-style adaptive-precision
+// geometric predicates: orient2d, the sign of the signed area of a
+// triangle, and incircle, the sign of whether a point falls inside
+// the circle through three others. Both take a fast path that
+// computes the answer as a plain float64 and only fall back to exact
+// expansion arithmetic - values represented as a slice of
+// non-overlapping float64 components that sum exactly to the true
+// result - when the fast path's own rounding error bound leaves the
+// sign in doubt.
+package predicates
+
+import "math"
+
+// epsilon is half the float64 machine epsilon (2^-53), the constant
+// Shewchuk's error-bound derivations are built from.
+const epsilon = 1.1102230246251565e-16
+
+// ccwErrBoundA bounds the rounding error of orient2d's fast float64
+// path, scaled by the magnitude of its inputs (detsum below).
+const ccwErrBoundA = (3.0 + 16.0*epsilon) * epsilon
+
+// iccErrBoundA is incircle's equivalent of ccwErrBoundA.
+const iccErrBoundA = (10.0 + 96.0*epsilon) * epsilon
+
+// splitter divides a float64 into two 26-bit-mantissa halves (Dekker's
+// algorithm): 2^27+1, so splitter*a has exactly ceil(27) more bits of
+// precision than a can hold, forcing the multiply-then-subtract below
+// to isolate the high bits exactly.
+const splitter = 134217729.0
+
+// twoSum returns x, y such that x+y == a+b exactly, with x the
+// correctly-rounded float64 sum and y the rounding error that was
+// dropped.
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bv := x - a
+	av := x - bv
+	br := b - bv
+	ar := a - av
+	y = ar + br
+	return
+}
+
+// twoDiff returns x, y such that x+y == a-b exactly.
+func twoDiff(a, b float64) (x, y float64) {
+	x = a - b
+	bv := a - x
+	av := x + bv
+	br := bv - b
+	ar := a - av
+	y = ar + br
+	return
+}
+
+// split breaks a into two halves, hi and lo, each representable with
+// half of float64's mantissa bits, such that a == hi+lo and the two
+// halves don't share any bits (so their pairwise products below can't
+// lose precision to rounding).
+func split(a float64) (hi, lo float64) {
+	c := splitter * a
+	hi = c - (c - a)
+	lo = a - hi
+	return
+}
+
+// twoProduct returns x, y such that x+y == a*b exactly.
+func twoProduct(a, b float64) (x, y float64) {
+	x = a * b
+	ahi, alo := split(a)
+	bhi, blo := split(b)
+	err1 := x - ahi*bhi
+	err2 := err1 - alo*bhi
+	err3 := err2 - ahi*blo
+	y = alo*blo - err3
+	return
+}
+
+// expansionSum merges two expansions, e and f - each a slice of
+// components summing exactly to some value, ordered by increasing
+// magnitude - into one expansion summing exactly to e's value plus
+// f's. It is Shewchuk's fast-expansion-sum: components are merged by
+// magnitude and then folded left to right with twoSum, so every
+// dropped rounding error is retained as its own output component and
+// the result sums, in float64 arithmetic, to the exact total
+// regardless of the merge order.
+func expansionSum(e, f []float64) []float64 {
+	merged := make([]float64, 0, len(e)+len(f))
+	i, j := 0, 0
+	for i < len(e) && j < len(f) {
+		if math.Abs(e[i]) < math.Abs(f[j]) {
+			merged = append(merged, e[i])
+			i++
+		} else {
+			merged = append(merged, f[j])
+			j++
+		}
+	}
+	merged = append(merged, e[i:]...)
+	merged = append(merged, f[j:]...)
+	if len(merged) == 0 {
+		return merged
+	}
+
+	out := make([]float64, 0, len(merged))
+	q := merged[0]
+	for _, m := range merged[1:] {
+		sum, err := twoSum(q, m)
+		if err != 0 {
+			out = append(out, err)
+		}
+		q = sum
+	}
+	if q != 0 || len(out) == 0 {
+		out = append(out, q)
+	}
+	return out
+}
+
+// negateExpansion returns an expansion summing to -1 times e's value.
+func negateExpansion(e []float64) []float64 {
+	out := make([]float64, len(e))
+	for i, v := range e {
+		out[i] = -v
+	}
+	return out
+}
+
+// expansionDiff returns an expansion summing exactly to e's value
+// minus f's.
+func expansionDiff(e, f []float64) []float64 {
+	return expansionSum(e, negateExpansion(f))
+}
+
+// expansionProduct returns an expansion summing exactly to the
+// product of e's value and f's, by distributing twoProduct over every
+// pair of components and folding each 2-term result in with
+// expansionSum. It costs O(len(e)*len(f)) twoProducts, fine for the
+// handful of terms orient2d/incircle ever build.
+func expansionProduct(e, f []float64) []float64 {
+	var result []float64
+	for _, ei := range e {
+		for _, fj := range f {
+			hi, lo := twoProduct(ei, fj)
+			result = expansionSum(result, []float64{lo, hi})
+		}
+	}
+	return result
+}
+
+// sumExpansion collapses e to the single float64 closest to its exact
+// value, by adding its components from smallest to largest magnitude
+// (the ordering that loses the least precision to rounding).
+func sumExpansion(e []float64) float64 {
+	var s float64
+	for _, v := range e {
+		s += v
+	}
+	return s
+}
+
+// Orient2D returns a value whose sign is the sign of the exact
+// determinant
+//
+//	| ax-cx  ay-cy |
+//	| bx-cx  by-cy |
+//
+// i.e. positive when a, b, c run counterclockwise, negative when
+// clockwise, and exactly zero when the three points are collinear. A
+// plain float64 computation is tried first; it is only abandoned, in
+// favour of exact expansion arithmetic, when its own rounding error
+// bound can't rule out the sign being wrong.
+func Orient2D(ax, ay, bx, by, cx, cy float64) float64 {
+	acx := ax - cx
+	bcx := bx - cx
+	acy := ay - cy
+	bcy := by - cy
+
+	detleft := acx * bcy
+	detright := acy * bcx
+	det := detleft - detright
+
+	var detsum float64
+	switch {
+	case detleft > 0:
+		if detright <= 0 {
+			return det
+		}
+		detsum = detleft + detright
+	case detleft < 0:
+		if detright >= 0 {
+			return det
+		}
+		detsum = -detleft - detright
+	default:
+		return det
+	}
+	errbound := ccwErrBoundA * detsum
+	if det >= errbound || -det >= errbound {
+		return det
+	}
+	return Orient2DExact(ax, ay, bx, by, cx, cy)
+}
+
+// Orient2DExact is Orient2D's exact fallback: it always computes the
+// determinant with expansion arithmetic, skipping the fast float64
+// path (and its error-bound check) entirely.
+func Orient2DExact(ax, ay, bx, by, cx, cy float64) float64 {
+	acx, acxe := twoDiff(ax, cx)
+	acy, acye := twoDiff(ay, cy)
+	bcx, bcxe := twoDiff(bx, cx)
+	bcy, bcye := twoDiff(by, cy)
+
+	detleft := expansionProduct([]float64{acxe, acx}, []float64{bcye, bcy})
+	detright := expansionProduct([]float64{acye, acy}, []float64{bcxe, bcx})
+	return sumExpansion(expansionDiff(detleft, detright))
+}
+
+// InCircle returns a value whose sign is the sign of the exact
+// determinant
+//
+//	| ax-dx  ay-dy  (ax-dx)^2+(ay-dy)^2 |
+//	| bx-dx  by-dy  (bx-dx)^2+(by-dy)^2 |
+//	| cx-dx  cy-dy  (cx-dx)^2+(cy-dy)^2 |
+//
+// which is positive iff d lies inside the circle through a, b, c
+// taken counterclockwise (and negative if taken clockwise), assuming
+// a, b, c are given counterclockwise. As with Orient2D, a plain
+// float64 computation is tried first and exact expansion arithmetic
+// is only used as a fallback.
+func InCircle(ax, ay, bx, by, cx, cy, dx, dy float64) float64 {
+	adx := ax - dx
+	ady := ay - dy
+	bdx := bx - dx
+	bdy := by - dy
+	cdx := cx - dx
+	cdy := cy - dy
+
+	abdet := adx*bdy - bdx*ady
+	bcdet := bdx*cdy - cdx*bdy
+	cadet := cdx*ady - adx*cdy
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+
+	det := alift*bcdet + blift*cadet + clift*abdet
+
+	permanent := math.Abs(alift*bcdet) + math.Abs(blift*cadet) + math.Abs(clift*abdet)
+	errbound := iccErrBoundA * permanent
+	if det > errbound || -det > errbound {
+		return det
+	}
+	return InCircleExact(ax, ay, bx, by, cx, cy, dx, dy)
+}
+
+// InCircleExact is InCircle's exact fallback: every subtraction,
+// product, and sum in the determinant is carried out with expansion
+// arithmetic instead of plain float64 operations, so the returned
+// value's sign always matches the exact determinant's.
+func InCircleExact(ax, ay, bx, by, cx, cy, dx, dy float64) float64 {
+	adx, adxe := twoDiff(ax, dx)
+	ady, adye := twoDiff(ay, dy)
+	bdx, bdxe := twoDiff(bx, dx)
+	bdy, bdye := twoDiff(by, dy)
+	cdx, cdxe := twoDiff(cx, dx)
+	cdy, cdye := twoDiff(cy, dy)
+
+	ade := []float64{adxe, adx}
+	adye2 := []float64{adye, ady}
+	bde := []float64{bdxe, bdx}
+	bdye2 := []float64{bdye, bdy}
+	cde := []float64{cdxe, cdx}
+	cdye2 := []float64{cdye, cdy}
+
+	abdet := expansionDiff(expansionProduct(ade, bdye2), expansionProduct(bde, adye2))
+	bcdet := expansionDiff(expansionProduct(bde, cdye2), expansionProduct(cde, bdye2))
+	cadet := expansionDiff(expansionProduct(cde, adye2), expansionProduct(ade, cdye2))
+
+	alift := expansionSum(expansionProduct(ade, ade), expansionProduct(adye2, adye2))
+	blift := expansionSum(expansionProduct(bde, bde), expansionProduct(bdye2, bdye2))
+	clift := expansionSum(expansionProduct(cde, cde), expansionProduct(cdye2, cdye2))
+
+	total := expansionSum(expansionSum(expansionProduct(alift, bcdet), expansionProduct(blift, cadet)), expansionProduct(clift, abdet))
+	return sumExpansion(total)
+}
@@ -0,0 +1,70 @@
+package predicates
+
+import "testing"
+
+func TestOrient2DCounterClockwise(t *testing.T) {
+	if got := Orient2D(0, 0, 1, 0, 0, 1); got <= 0 {
+		t.Errorf("Orient2D((0,0),(1,0),(0,1)) = %v, want > 0", got)
+	}
+}
+
+func TestOrient2DClockwise(t *testing.T) {
+	if got := Orient2D(0, 0, 0, 1, 1, 0); got >= 0 {
+		t.Errorf("Orient2D((0,0),(0,1),(1,0)) = %v, want < 0", got)
+	}
+}
+
+func TestOrient2DCollinear(t *testing.T) {
+	if got := Orient2D(0, 0, 1, 1, 2, 2); got != 0 {
+		t.Errorf("Orient2D((0,0),(1,1),(2,2)) = %v, want 0", got)
+	}
+}
+
+func TestOrient2DExactMatchesFastPath(t *testing.T) {
+	cases := []struct{ ax, ay, bx, by, cx, cy float64 }{
+		{0, 0, 1, 0, 0, 1},
+		{0, 0, 0, 1, 1, 0},
+		{1.5, 2.5, -3.25, 4.75, 0.1, -0.2},
+	}
+	for _, c := range cases {
+		fast := Orient2D(c.ax, c.ay, c.bx, c.by, c.cx, c.cy)
+		exact := Orient2DExact(c.ax, c.ay, c.bx, c.by, c.cx, c.cy)
+		if (fast > 0) != (exact > 0) || (fast < 0) != (exact < 0) {
+			t.Errorf("Orient2D=%v, Orient2DExact=%v disagree in sign for %+v", fast, exact, c)
+		}
+	}
+}
+
+func TestOrient2DNearlyCollinearAgreesWithBigPrecisionSign(t *testing.T) {
+	// A triple whose naive float64 cross product is vulnerable to
+	// cancellation: a, b, c nearly collinear but offset by an
+	// amount far below the coordinates' own magnitude.
+	ax, ay := 1e8, 1e8
+	bx, by := 1e8+1, 1e8+1
+	cx, cy := 1e8+2, 1e8+2+1e-8
+	got := Orient2D(ax, ay, bx, by, cx, cy)
+	if got <= 0 {
+		t.Errorf("Orient2D = %v, want a positive (counterclockwise) sign", got)
+	}
+}
+
+func TestInCirclePointInside(t *testing.T) {
+	// Unit circle through (1,0), (0,1), (-1,0); (0,0.5) is inside.
+	if got := InCircle(1, 0, 0, 1, -1, 0, 0, 0.5); got <= 0 {
+		t.Errorf("InCircle = %v, want > 0 (inside)", got)
+	}
+}
+
+func TestInCirclePointOutside(t *testing.T) {
+	if got := InCircle(1, 0, 0, 1, -1, 0, 10, 10); got >= 0 {
+		t.Errorf("InCircle = %v, want < 0 (outside)", got)
+	}
+}
+
+func TestInCircleExactMatchesFastPath(t *testing.T) {
+	fast := InCircle(1, 0, 0, 1, -1, 0, 0, 0.5)
+	exact := InCircleExact(1, 0, 0, 1, -1, 0, 0, 0.5)
+	if (fast > 0) != (exact > 0) {
+		t.Errorf("InCircle=%v, InCircleExact=%v disagree in sign", fast, exact)
+	}
+}
@@ -0,0 +1,208 @@
+package polygon
+
+import (
+	"fmt"
+	"math"
+)
+
+// EndCap selects how BufferLine finishes the two open ends of a path
+// being buffered into a filled stroke. It has no counterpart for a
+// closed ring: a ring has no ends, so (*Shape).Offset and
+// (*Shapes).Offset don't take one.
+type EndCap int
+
+const (
+	// CapButt finishes the end flush with the path, square to the
+	// path direction.
+	CapButt EndCap = iota
+	// CapSquare extends the end by halfWidth beyond the path,
+	// squared off.
+	CapSquare
+	// CapRound finishes the end with a semicircular arc of radius
+	// halfWidth, sampled the same way JoinRound samples a corner.
+	CapRound
+)
+
+// endCapPoints returns the points, if any, needed to join the
+// left-side offset point to the right-side offset point at the open
+// end v of a path whose last segment direction (pointing away from the
+// path, towards v) is dir. It does not include the left or right
+// offset points themselves, which the caller already has.
+func endCapPoints(v, dir Point, halfWidth float64, cap EndCap, arcTol float64) []Point {
+	left := Point{X: dir.Y, Y: -dir.X}
+	switch cap {
+	case CapSquare:
+		return []Point{
+			v.AddX(left, halfWidth).AddX(dir, halfWidth),
+			v.AddX(left, -halfWidth).AddX(dir, halfWidth),
+		}
+	case CapRound:
+		leftAngle := math.Atan2(left.Y, left.X)
+		steps := int(math.Ceil(math.Pi / roundStep(halfWidth, arcTol)))
+		if steps < 1 {
+			steps = 1
+		}
+		var out []Point
+		for k := 1; k < steps; k++ {
+			a := leftAngle + math.Pi*float64(k)/float64(steps)
+			out = append(out, Point{X: v.X + halfWidth*math.Cos(a), Y: v.Y + halfWidth*math.Sin(a)})
+		}
+		return out
+	default: // CapButt
+		return nil
+	}
+}
+
+// BufferLine returns the filled outline of path, an open polyline of
+// two or more points, stroked to total width 2*halfWidth. Interior
+// corners between consecutive segments are filled the same way
+// (*Shape).Offset fills a corner that opens up under inflation: join,
+// miterLimit and arcTol have the same meaning there. The two open ends
+// are finished per cap. Any self-overlap a tight join or a sharp
+// doubling-back in path introduces is dissolved the same way
+// (*Shape).Offset cleans up its own output, by running the raw
+// outline through Clip's OpUnion pass.
+func BufferLine(path []Point, halfWidth float64, join JoinStyle, cap EndCap, miterLimit, arcTol float64) (*Shapes, error) {
+	if len(path) < 2 {
+		return nil, fmt.Errorf("BufferLine requires at least 2 points, got %d", len(path))
+	}
+	if halfWidth <= 0 {
+		return nil, fmt.Errorf("BufferLine requires a positive halfWidth, got %v", halfWidth)
+	}
+	n := len(path) - 1
+	units := make([]Point, n)
+	for i := 0; i < n; i++ {
+		u, err := path[i].Unit(path[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("path has a degenerate segment at %d: %v", i, err)
+		}
+		units[i] = u
+	}
+	left := func(i int) Point { return Point{X: units[i].Y, Y: -units[i].X} }
+	right := func(i int) Point { return Point{X: -units[i].Y, Y: units[i].X} }
+
+	var out []Point
+	// Walk the left side from start to end.
+	out = append(out, path[0].AddX(left(0), halfWidth))
+	for j := 1; j < n; j++ {
+		p1 := path[j].AddX(left(j-1), halfWidth)
+		p2 := path[j].AddX(left(j), halfWidth)
+		out = append(out, joinCorner(path[j], p1, p2, units[j-1], units[j], halfWidth, join, miterLimit, arcTol)...)
+	}
+	out = append(out, path[n].AddX(left(n-1), halfWidth))
+
+	// Cap the far end.
+	out = append(out, endCapPoints(path[n], units[n-1], halfWidth, cap, arcTol)...)
+
+	// Walk the right side back from end to start. The reversed
+	// segment directions fed to joinCorner flip the sign of its
+	// convex/concave turn test, which is exactly what's needed for
+	// the opposite side of the stroke.
+	out = append(out, path[n].AddX(right(n-1), halfWidth))
+	for j := n - 1; j > 0; j-- {
+		p1 := path[j].AddX(right(j), halfWidth)
+		p2 := path[j].AddX(right(j-1), halfWidth)
+		ru := Point{X: -units[j].X, Y: -units[j].Y}
+		ru2 := Point{X: -units[j-1].X, Y: -units[j-1].Y}
+		out = append(out, joinCorner(path[j], p1, p2, ru, ru2, halfWidth, join, miterLimit, arcTol)...)
+	}
+	out = append(out, path[0].AddX(right(0), halfWidth))
+
+	// Cap the near end.
+	out = append(out, endCapPoints(path[0], Point{X: -units[0].X, Y: -units[0].Y}, halfWidth, cap, arcTol)...)
+
+	poly, err := Rationalize(out)
+	if err != nil {
+		return nil, err
+	}
+	return (&Shapes{}).Include(poly).Clip(&Shapes{}, OpUnion, FillNonZero), nil
+}
+
+// BufferOption configures (*Shapes).Buffer.
+type BufferOption func(*bufferOptions)
+
+type bufferOptions struct {
+	join               JoinStyle
+	cap                EndCap
+	miterLimit, arcTol float64
+}
+
+// WithJoin overrides the corner join style Buffer uses, JoinMiter by
+// default.
+func WithJoin(join JoinStyle) BufferOption {
+	return func(o *bufferOptions) { o.join = join }
+}
+
+// WithCap overrides the end cap style Buffer uses, CapButt by
+// default.
+func WithCap(cap EndCap) BufferOption {
+	return func(o *bufferOptions) { o.cap = cap }
+}
+
+// WithMiterLimit overrides the miter limit Buffer passes to
+// JoinMiter, 4 by default.
+func WithMiterLimit(limit float64) BufferOption {
+	return func(o *bufferOptions) { o.miterLimit = limit }
+}
+
+// WithArcTolerance overrides the arc tolerance Buffer passes to
+// JoinRound/CapRound, 0.1 by default.
+func WithArcTolerance(tol float64) BufferOption {
+	return func(o *bufferOptions) { o.arcTol = tol }
+}
+
+// BufferLine returns the filled outline of the open polyline path,
+// stroked to total width 2*halfWidth, via the package-level BufferLine
+// with JoinMiter, CapButt, miterLimit 4 and arcTol 0.1, each
+// overridable by opts. p is unused; the method form exists so that
+// buffering sits alongside the rest of this package's Shapes API and
+// takes the same BufferOption values as Buffer. For cleaning up p's own closed
+// shapes, use (*Shapes).Buffer instead: Shapes has no open-polyline
+// type of its own for this method to read a path off of, so the path
+// to stroke is always passed explicitly.
+func (p *Shapes) BufferLine(path []Point, halfWidth float64, opts ...BufferOption) (*Shapes, error) {
+	o := bufferOptions{join: JoinMiter, cap: CapButt, miterLimit: 4, arcTol: 0.1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return BufferLine(path, halfWidth, o.join, o.cap, o.miterLimit, o.arcTol)
+}
+
+// Buffer cleans up p's own shapes with an inflate-union-deflate pass
+// at distance: every shape in p is offset outward by distance (inward,
+// if distance is negative) with (*Shape).Offset, the results are
+// merged the same way (*Shape).Offset and MinkowskiSum already merge
+// their own intermediate pieces - a Clip OpUnion pass - and the merged
+// outline is then offset back by -distance. Closing a gap narrower
+// than 2*distance and fusing two shapes that only touch or overlap by
+// a sliver are the usual reasons to reach for this; p itself is left
+// unmodified. opts configure both Offset passes exactly as they
+// configure BufferLine, except WithCap, which has no effect here since
+// a closed ring has no ends to cap.
+func (p *Shapes) Buffer(distance float64, opts ...BufferOption) (*Shapes, error) {
+	if p == nil {
+		return nil, fmt.Errorf("cannot buffer a nil Shapes")
+	}
+	o := bufferOptions{join: JoinMiter, cap: CapButt, miterLimit: 4, arcTol: 0.1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	grown := &Shapes{}
+	for _, s := range p.P {
+		out, err := s.Offset(distance, o.join, o.miterLimit, o.arcTol)
+		if err != nil {
+			return nil, err
+		}
+		grown.P = append(grown.P, out.P...)
+	}
+	merged := grown.Clip(&Shapes{}, OpUnion, FillNonZero)
+	shrunk := &Shapes{}
+	for _, s := range merged.P {
+		out, err := s.Offset(-distance, o.join, o.miterLimit, o.arcTol)
+		if err != nil {
+			return nil, err
+		}
+		shrunk.P = append(shrunk.P, out.P...)
+	}
+	return shrunk.Clip(&Shapes{}, OpUnion, FillNonZero), nil
+}
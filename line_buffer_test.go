@@ -0,0 +1,117 @@
+package polygon
+
+import "testing"
+
+func TestBufferLineStraightButt(t *testing.T) {
+	got, err := BufferLine([]Point{{0, 0}, {4, 0}}, 1, JoinMiter, CapButt, 4, 0.1)
+	if err != nil {
+		t.Fatalf("BufferLine failed: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d", len(got.P))
+	}
+	checkPoints(t, "straight butt", got.P[0].PS, square(Point{0, -1}, Point{4, 1}))
+}
+
+func TestBufferLineCornerMiter(t *testing.T) {
+	got, err := BufferLine([]Point{{0, 0}, {4, 0}, {4, 4}}, 1, JoinMiter, CapButt, 4, 0.1)
+	if err != nil {
+		t.Fatalf("BufferLine failed: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d", len(got.P))
+	}
+	checkPoints(t, "corner miter", got.P[0].PS, []Point{
+		{0, -1}, {5, -1}, {5, 4}, {3, 4}, {3, 1}, {0, 1},
+	})
+}
+
+func TestBufferLineSquareCap(t *testing.T) {
+	got, err := BufferLine([]Point{{0, 0}, {4, 0}}, 1, JoinMiter, CapSquare, 4, 0.1)
+	if err != nil {
+		t.Fatalf("BufferLine failed: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d", len(got.P))
+	}
+	checkPoints(t, "square cap", got.P[0].PS, square(Point{-1, -1}, Point{5, 1}))
+}
+
+func TestBufferLineRoundCap(t *testing.T) {
+	got, err := BufferLine([]Point{{0, 0}, {4, 0}}, 1, JoinMiter, CapRound, 4, 0.1)
+	if err != nil {
+		t.Fatalf("BufferLine failed: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d", len(got.P))
+	}
+	straight := len(square(Point{0, -1}, Point{4, 1}))
+	if len(got.P[0].PS) <= straight {
+		t.Errorf("round cap should sample additional points around each end, got %d points", len(got.P[0].PS))
+	}
+}
+
+func TestBufferLineRejectsShortPath(t *testing.T) {
+	if _, err := BufferLine([]Point{{0, 0}}, 1, JoinMiter, CapButt, 4, 0.1); err == nil {
+		t.Errorf("expected an error for a path with fewer than 2 points")
+	}
+}
+
+func TestBufferLineRejectsNonPositiveWidth(t *testing.T) {
+	if _, err := BufferLine([]Point{{0, 0}, {1, 0}}, 0, JoinMiter, CapButt, 4, 0.1); err == nil {
+		t.Errorf("expected an error for a non-positive halfWidth")
+	}
+}
+
+func TestShapesBufferLineDefaults(t *testing.T) {
+	var p *Shapes
+	got, err := p.BufferLine([]Point{{0, 0}, {4, 0}}, 1)
+	if err != nil {
+		t.Fatalf("BufferLine failed: %v", err)
+	}
+	checkPoints(t, "BufferLine defaults", got.P[0].PS, square(Point{0, -1}, Point{4, 1}))
+}
+
+func TestShapesBufferLineOptions(t *testing.T) {
+	var p *Shapes
+	got, err := p.BufferLine([]Point{{0, 0}, {4, 0}}, 1, WithCap(CapSquare))
+	if err != nil {
+		t.Fatalf("BufferLine failed: %v", err)
+	}
+	checkPoints(t, "BufferLine with square cap", got.P[0].PS, square(Point{-1, -1}, Point{5, 1}))
+}
+
+func TestShapesBufferBridgesNarrowGap(t *testing.T) {
+	// Two squares that touch only along a single edge segment: a plain
+	// Clip OpUnion would keep them as one outline already here, so
+	// instead leave a narrow gap between them that only a positive
+	// Buffer distance can bridge.
+	var p *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{2, 2})...)
+	p = p.Builder(square(Point{2.2, 0}, Point{4.2, 2})...)
+
+	got, err := p.Buffer(0.2)
+	if err != nil {
+		t.Fatalf("Buffer failed: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("expecting the gap to be bridged into a single shape, got %d shapes", len(got.P))
+	}
+}
+
+func TestShapesBufferShrinksBack(t *testing.T) {
+	// A lone square, buffered by zero net distance via equal and
+	// opposite inflate/deflate passes, should come back out unchanged
+	// in extent.
+	var p *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{4, 4})...)
+
+	got, err := p.Buffer(1)
+	if err != nil {
+		t.Fatalf("Buffer failed: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d", len(got.P))
+	}
+	checkPoints(t, "Buffer round trip", got.P[0].PS, square(Point{0, 0}, Point{4, 4}))
+}
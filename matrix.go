@@ -0,0 +1,115 @@
+package polygon
+
+import (
+	"fmt"
+	"math"
+)
+
+// Matrix is a 2D affine transform, applying to a Point as:
+//
+//	x' = A*x + C*y + E
+//	y' = B*x + D*y + F
+//
+// (the SVG/PostScript convention). The zero value is the all-zero
+// matrix, which collapses every point to the origin; use Identity
+// for a no-op transform.
+type Matrix struct {
+	A, B, C, D, E, F float64
+}
+
+// Identity returns the no-op transform.
+func Identity() Matrix {
+	return Matrix{A: 1, D: 1}
+}
+
+// Translate returns a transform that shifts points by (dx, dy).
+func Translate(dx, dy float64) Matrix {
+	return Matrix{A: 1, D: 1, E: dx, F: dy}
+}
+
+// Rotate returns a transform that rotates points by theta radians
+// (+ve = counter-clockwise) about the origin.
+func Rotate(theta float64) Matrix {
+	s, c := math.Sin(theta), math.Cos(theta)
+	return Matrix{A: c, B: s, C: -s, D: c}
+}
+
+// Scale returns a transform that scales points by sx in X and sy in
+// Y, about the origin.
+func Scale(sx, sy float64) Matrix {
+	return Matrix{A: sx, D: sy}
+}
+
+// ShearX returns a transform that shifts each point in X by k times
+// its Y coordinate.
+func ShearX(k float64) Matrix {
+	return Matrix{A: 1, C: k, D: 1}
+}
+
+// ShearY returns a transform that shifts each point in Y by k times
+// its X coordinate.
+func ShearY(k float64) Matrix {
+	return Matrix{A: 1, B: k, D: 1}
+}
+
+// Apply transforms a single point by m.
+func (m Matrix) Apply(p Point) Point {
+	return Point{
+		X: m.A*p.X + m.C*p.Y + m.E,
+		Y: m.B*p.X + m.D*p.Y + m.F,
+	}
+}
+
+// Mul composes m with other, returning the transform equivalent to
+// applying m first and then other: for any Point p,
+//
+//	m.Mul(other).Apply(p) == other.Apply(m.Apply(p))
+func (m Matrix) Mul(other Matrix) Matrix {
+	return Matrix{
+		A: other.A*m.A + other.C*m.B,
+		B: other.B*m.A + other.D*m.B,
+		C: other.A*m.C + other.C*m.D,
+		D: other.B*m.C + other.D*m.D,
+		E: other.A*m.E + other.C*m.F + other.E,
+		F: other.B*m.E + other.D*m.F + other.F,
+	}
+}
+
+// Inverse returns the transform that undoes m. It returns an error
+// if m is singular (its linear part has zero determinant), such as
+// a transform that collapses points onto a line.
+func (m Matrix) Inverse() (Matrix, error) {
+	det := m.A*m.D - m.B*m.C
+	if math.Abs(det) < Zeroish2 {
+		return Matrix{}, fmt.Errorf("matrix %#v is singular", m)
+	}
+	inv := Matrix{
+		A: m.D / det,
+		B: -m.B / det,
+		C: -m.C / det,
+		D: m.A / det,
+	}
+	inv.E = -(inv.A*m.E + inv.C*m.F)
+	inv.F = -(inv.B*m.E + inv.D*m.F)
+	return inv, nil
+}
+
+// Apply returns a copy of p with every shape transformed by m. A
+// reflection (m's linear part has a negative determinant) reverses
+// the winding of every shape's points, which Rationalize detects and
+// reflects in the rebuilt shape's Hole value: under a mirrored
+// transform a solid comes back as a hole and a hole as a solid.
+func (p *Shapes) Apply(m Matrix) *Shapes {
+	if p == nil {
+		return nil
+	}
+	var sh *Shapes
+	for _, v := range p.P {
+		var pts []Point
+		for _, pt := range v.PS {
+			pts = append(pts, m.Apply(pt))
+		}
+		sh = sh.Builder(pts...)
+	}
+	return sh
+}
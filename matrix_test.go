@@ -0,0 +1,81 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrixApplyPoint(t *testing.T) {
+	got := Rotate(math.Pi / 2).Apply(Point{1, 0})
+	if !MatchPoint(got, Point{0, 1}) {
+		t.Errorf("rotate 90 of (1,0) = %v, want (0,1)", got)
+	}
+	got = Translate(2, 3).Apply(Point{1, 1})
+	if !MatchPoint(got, Point{3, 4}) {
+		t.Errorf("translate = %v, want (3,4)", got)
+	}
+	got = Scale(2, 3).Apply(Point{1, 1})
+	if !MatchPoint(got, Point{2, 3}) {
+		t.Errorf("scale = %v, want (2,3)", got)
+	}
+	got = ShearX(2).Apply(Point{1, 1})
+	if !MatchPoint(got, Point{3, 1}) {
+		t.Errorf("shearX = %v, want (3,1)", got)
+	}
+	got = ShearY(2).Apply(Point{1, 1})
+	if !MatchPoint(got, Point{1, 3}) {
+		t.Errorf("shearY = %v, want (1,3)", got)
+	}
+}
+
+func TestMatrixMul(t *testing.T) {
+	m := Translate(1, 0).Mul(Rotate(math.Pi / 2))
+	p := Point{1, 0}
+	want := Rotate(math.Pi / 2).Apply(Translate(1, 0).Apply(p))
+	got := m.Apply(p)
+	if !MatchPoint(got, want) {
+		t.Errorf("composed transform = %v, want %v", got, want)
+	}
+}
+
+func TestMatrixInverse(t *testing.T) {
+	m := Translate(2, -3).Mul(Rotate(0.7)).Mul(Scale(1.5, 0.5))
+	inv, err := m.Inverse()
+	if err != nil {
+		t.Fatalf("Inverse failed: %v", err)
+	}
+	p := Point{4, 5}
+	got := m.Mul(inv).Apply(p)
+	if !MatchPoint(got, p) {
+		t.Errorf("m * inverse(m) applied to %v = %v, want identity", p, got)
+	}
+
+	if _, err := Scale(0, 1).Inverse(); err == nil {
+		t.Errorf("expected an error inverting a singular matrix")
+	}
+}
+
+func TestShapesApplyReflectsHole(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{1, 1})...)
+	if ss.P[0].Hole {
+		t.Fatalf("expected the original square not to be a hole")
+	}
+	mirrored := ss.Apply(Scale(-1, 1))
+	if !mirrored.P[0].Hole {
+		t.Errorf("reflecting a shape should toggle its Hole flag")
+	}
+	checkPoints(t, "mirrored square", mirrored.P[0].PS, []Point{{-1, 0}, {-1, 1}, {0, 1}, {0, 0}})
+}
+
+func TestShapesApplyMatchesTransform(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{2, 2})...)
+	at, to := Point{1, 1}, Point{5, 5}
+	theta, scale := math.Pi/4, 1.5
+
+	want := ss.Transform(at, to, theta, scale)
+	m := Translate(-at.X, -at.Y).Mul(Rotate(theta)).Mul(Scale(scale, scale)).Mul(Translate(to.X, to.Y))
+	got := ss.Apply(m)
+	checkPoints(t, "transform vs apply", got.P[0].PS, want.P[0].PS)
+}
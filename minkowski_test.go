@@ -0,0 +1,98 @@
+package polygon
+
+import "testing"
+
+func TestMinkowskiSum(t *testing.T) {
+	a, err := Rationalize(square(Point{0, 0}, Point{1, 1}))
+	if err != nil {
+		t.Fatalf("failed to build a: %v", err)
+	}
+	b, err := Rationalize(square(Point{0, 0}, Point{2, 2}))
+	if err != nil {
+		t.Fatalf("failed to build b: %v", err)
+	}
+	sum, err := MinkowskiSum(a, b)
+	if err != nil {
+		t.Fatalf("MinkowskiSum failed: %v", err)
+	}
+	if len(sum.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d: %#v", len(sum.P), sum.P)
+	}
+	checkPoints(t, "minkowski sum", sum.P[0].PS, square(Point{0, 0}, Point{3, 3}))
+}
+
+func TestMinkowskiSumConcave(t *testing.T) {
+	a, err := Rationalize([]Point{{0, 0}, {2, 0}, {2, 2}, {1, 1}, {0, 2}})
+	if err != nil {
+		t.Fatalf("failed to build a: %v", err)
+	}
+	b, err := Rationalize(square(Point{0, 0}, Point{1, 1}))
+	if err != nil {
+		t.Fatalf("failed to build b: %v", err)
+	}
+	sum, err := MinkowskiSum(a, b)
+	if err != nil {
+		t.Fatalf("MinkowskiSum of a concave shape failed: %v", err)
+	}
+	if len(sum.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d: %#v", len(sum.P), sum.P)
+	}
+	// The notch in a's top edge, at (1,1), is still a reflex corner
+	// once inflated by b (its apex moves to (1.5,2.5)), so a point
+	// just past it, inside the cut-away V, should remain outside the
+	// sum while points well inside either lobe of a should fall
+	// inside it.
+	inside := []Point{{0.5, 0.5}, {1.5, 0.5}}
+	for _, p := range inside {
+		if !p.Inside(sum.P[0]) {
+			t.Errorf("expected %v to fall inside the concave Minkowski sum", p)
+		}
+	}
+	if (Point{1.5, 2.6}).Inside(sum.P[0]) {
+		t.Errorf("expected the point past the notch to fall outside the concave Minkowski sum")
+	}
+}
+
+func TestShapesMinkowskiSumSubtractsHoles(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	ss = ss.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	var bb *Shapes
+	bb = bb.Builder(square(Point{0, 0}, Point{1, 1})...)
+
+	got, err := ss.MinkowskiSum(bb)
+	if err != nil {
+		t.Fatalf("MinkowskiSum failed: %v", err)
+	}
+	if len(got.P) != 2 {
+		t.Fatalf("expecting an outer shape and a hole, got %d: %#v", len(got.P), got.P)
+	}
+	checkPoints(t, "outer sum", got.P[0].PS, square(Point{0, 0}, Point{11, 11}))
+	if !got.P[1].Hole {
+		t.Fatalf("expected the second shape to remain a hole")
+	}
+	checkPoints(t, "hole contribution subtracted", got.P[1].PS, []Point{
+		{4, 4}, {4, 7}, {7, 7}, {7, 4},
+	})
+}
+
+func TestMinkowskiDiffOverlap(t *testing.T) {
+	a, err := Rationalize(square(Point{0, 0}, Point{1, 1}))
+	if err != nil {
+		t.Fatalf("failed to build a: %v", err)
+	}
+	b, err := Rationalize(square(Point{0, 0}, Point{1, 1}))
+	if err != nil {
+		t.Fatalf("failed to build b: %v", err)
+	}
+	diff, err := MinkowskiDiff(a, b)
+	if err != nil {
+		t.Fatalf("MinkowskiDiff failed: %v", err)
+	}
+	if len(diff.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d: %#v", len(diff.P), diff.P)
+	}
+	if !(Point{0, 0}).Inside(diff.P[0]) {
+		t.Errorf("origin should fall inside the Minkowski difference of two overlapping shapes")
+	}
+}
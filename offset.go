@@ -0,0 +1,213 @@
+package polygon
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// JoinStyle selects how Offset fills the gap that opens up at a convex
+// corner when a shape is inflated (or at a reflex corner when it is
+// deflated).
+type JoinStyle int
+
+const (
+	// JoinMiter extends the two offset edges to their analytic
+	// intersection, falling back to a plain bevel (a straight cut from
+	// the end of one offset edge to the start of the next) when the
+	// miter length exceeds miterLimit*|delta|.
+	JoinMiter JoinStyle = iota
+	// JoinSquare chamfers the corner with a single flat cut, offset
+	// from the original vertex by |delta| along the angle bisector.
+	JoinSquare
+	// JoinRound fills the corner with an arc of radius |delta|,
+	// sampled at the angular step roundStep derives from arcTol.
+	JoinRound
+)
+
+// roundStep returns the angular step, in radians, JoinRound samples a
+// corner's arc of radius r at, derived from arcTol the way Clipper's
+// ClipperOffset does: the chord error of a step of angle theta on a
+// circle of radius r is r*(1-cos(theta)), so solving for theta at
+// error arcTol gives theta = acos(1 - arcTol/r). arcTol <= 0 or
+// exceeding the arc's own diameter both fall back to a half-turn step
+// (2 samples cover any sweep).
+func roundStep(r, arcTol float64) float64 {
+	if arcTol <= 0 || r <= 0 {
+		return math.Pi
+	}
+	cos := 1 - arcTol/r
+	if cos < -1 {
+		cos = -1
+	} else if cos > 1 {
+		cos = 1
+	}
+	return math.Acos(cos)
+}
+
+// joinCorner returns the points that fill the gap between the offset
+// edges ending at p1 and starting at p2, for the corner at original
+// vertex v where the incoming edge direction was uPrev and the
+// outgoing edge direction is uNext. delta, join, miterLimit and arcTol
+// have the same meaning as in (*Shape).Offset, which is join's only
+// caller for a closed ring; BufferLine reuses it unchanged for the
+// interior corners of an open path, passing the two walked directions
+// (forward along one side, reversed along the other) as uPrev/uNext so
+// the same convex/concave test applies on both sides of the stroke.
+func joinCorner(v, p1, p2, uPrev, uNext Point, delta float64, join JoinStyle, miterLimit, arcTol float64) []Point {
+	if MatchPoint(p1, p2) {
+		return []Point{p1}
+	}
+	turn := uPrev.X*uNext.Y - uPrev.Y*uNext.X
+	var mpt Point
+	haveMpt := math.Abs(turn) > Zeroish
+	if haveMpt {
+		diff := p2.AddX(p1, -1)
+		t := (diff.X*uNext.Y - diff.Y*uNext.X) / turn
+		mpt = p1.AddX(uPrev, t)
+	}
+	if turn*delta <= 0 {
+		// This corner closes up under this delta: the adjacent
+		// offset edges simply cross.
+		if haveMpt {
+			return []Point{mpt}
+		}
+		return []Point{p1, p2}
+	}
+	// This corner opens up under this delta and needs join geometry to
+	// fill the gap.
+	switch join {
+	case JoinRound:
+		startA := math.Atan2(p1.Y-v.Y, p1.X-v.X)
+		sweep := math.Atan2(p2.Y-v.Y, p2.X-v.X) - startA
+		for sweep <= -math.Pi {
+			sweep += 2 * math.Pi
+		}
+		for sweep > math.Pi {
+			sweep -= 2 * math.Pi
+		}
+		r := math.Abs(delta)
+		steps := int(math.Ceil(math.Abs(sweep) / roundStep(r, arcTol)))
+		if steps < 1 {
+			steps = 1
+		}
+		out := []Point{p1}
+		for k := 1; k < steps; k++ {
+			a := startA + sweep*float64(k)/float64(steps)
+			out = append(out, Point{X: v.X + r*math.Cos(a), Y: v.Y + r*math.Sin(a)})
+		}
+		return append(out, p2)
+	case JoinSquare:
+		bis, err := v.Unit(Point{X: p1.X + p2.X - v.X, Y: p1.Y + p2.Y - v.Y})
+		if err != nil {
+			return []Point{p1, p2}
+		}
+		// The cut runs square to bis through q; each offset edge is
+		// extended (or trimmed) along its own direction to meet it.
+		q := v.AddX(bis, math.Abs(delta))
+		dPrev, dNext := uPrev.Dot(bis), uNext.Dot(bis)
+		if math.Abs(dPrev) < Zeroish || math.Abs(dNext) < Zeroish {
+			return []Point{p1, p2}
+		}
+		return []Point{
+			p1.AddX(uPrev, q.AddX(p1, -1).Dot(bis)/dPrev),
+			p2.AddX(uNext, q.AddX(p2, -1).Dot(bis)/dNext),
+		}
+	default: // JoinMiter
+		if haveMpt {
+			dX, dY := mpt.X-v.X, mpt.Y-v.Y
+			if math.Hypot(dX, dY) <= miterLimit*math.Abs(delta) {
+				return []Point{mpt}
+			}
+		}
+		return []Point{p1, p2} // miter limit exceeded: bevel.
+	}
+}
+
+// Offset returns s inflated by delta (deflated if delta is negative),
+// as a newly allocated Shapes value; s is unmodified. Holes are offset
+// in the opposite sense automatically, so inflating a shape with a
+// hole shrinks the hole. Join selects how the corners that open up are
+// filled: JoinMiter extends the two offset edges to their analytic
+// intersection, falling back to a plain bevel between the two offset
+// edges' ends once the miter length exceeds miterLimit*|delta|;
+// JoinSquare chamfers with a single flat cut |delta| from the vertex
+// along the angle bisector; JoinRound fills the corner with an arc, sampled
+// every roundStep(|delta|, arcTol) radians. Corners that instead close
+// up under the requested delta are always resolved to the single
+// point where the adjacent offset edges cross, which is how deflation
+// can fold a deep reflex corner back on itself; any resulting
+// self-overlap (from a sharp deflation, or concave corners folding
+// across one another) is then dissolved by running the offset outline
+// through Clip's OpUnion pass, so the result is always a clean,
+// non-self-intersecting set of shapes.
+func (s *Shape) Offset(delta float64, join JoinStyle, miterLimit, arcTol float64) (*Shapes, error) {
+	if s == nil {
+		return nil, fmt.Errorf("cannot offset a nil shape")
+	}
+	if delta == 0 {
+		return (&Shapes{}).Include(s.Duplicate()), nil
+	}
+	base, err := s.dissolve()
+	if err != nil {
+		return nil, err
+	}
+	pts := base.PS
+	// A hole is wound clockwise, so rotating each edge direction by
+	// -90 degrees to find its outward normal (below) already points
+	// into the hole instead of away from it: the same positive delta
+	// that inflates a solid shape automatically shrinks a hole cut
+	// from one.
+	n := len(pts)
+	units := make([]Point, n)
+	for i := range pts {
+		u, err := pts[i].Unit(pts[(i+1)%n])
+		if err != nil {
+			return nil, fmt.Errorf("shape %q has a degenerate edge: %v", s.Index, err)
+		}
+		units[i] = u
+	}
+	oa := make([]Point, n) // offset start of edge i
+	ob := make([]Point, n) // offset end of edge i
+	for i := range pts {
+		normal := Point{X: units[i].Y, Y: -units[i].X}
+		oa[i] = pts[i].AddX(normal, delta)
+		ob[i] = pts[(i+1)%n].AddX(normal, delta)
+	}
+
+	var out []Point
+	for j := 0; j < n; j++ {
+		prev := (j - 1 + n) % n
+		out = append(out, joinCorner(pts[j], ob[prev], oa[j], units[prev], units[j], delta, join, miterLimit, arcTol)...)
+	}
+	poly, err := Rationalize(out)
+	if err != nil {
+		return nil, err
+	}
+	poly.Index = s.Index
+	cleaned := (&Shapes{}).Include(poly).Clip(&Shapes{}, OpUnion, FillNonZero)
+	for _, c := range cleaned.P {
+		// Clip has no notion of a lone ring being "someone else's
+		// hole"; it always normalizes an isolated ring to the solid
+		// orientation. Flip it back if that doesn't match s.
+		if c.Hole != s.Hole {
+			slices.Reverse(c.PS[1:])
+			c.Hole = s.Hole
+		}
+	}
+	if len(cleaned.P) == 1 {
+		cleaned.P[0].Index = s.Index
+	}
+	return cleaned, nil
+}
+
+// Offset returns the indexed shape inflated (or deflated) by delta, as
+// a newly allocated Shapes value; p is left unmodified. See
+// (*Shape).Offset for the meaning of delta, join, miterLimit and
+// arcTol.
+func (p *Shapes) Offset(n int, delta float64, join JoinStyle, miterLimit, arcTol float64) (*Shapes, error) {
+	if p == nil || n < 0 || n >= len(p.P) {
+		return nil, fmt.Errorf("invalid polygon=%d in shapes (%d known)", n, len(p.P))
+	}
+	return p.P[n].Offset(delta, join, miterLimit, arcTol)
+}
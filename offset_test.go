@@ -0,0 +1,63 @@
+package polygon
+
+import "testing"
+
+func TestOffsetMiter(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{2, 2})...)
+	got, err := ss.Offset(0, 1, JoinMiter, 4, 0.1)
+	if err != nil {
+		t.Fatalf("inflate failed: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d", len(got.P))
+	}
+	checkPoints(t, "inflate miter", got.P[0].PS, square(Point{-1, -1}, Point{3, 3}))
+
+	ss = nil
+	ss = ss.Builder(square(Point{0, 0}, Point{4, 4})...)
+	got, err = ss.Offset(0, -1, JoinMiter, 4, 0.1)
+	if err != nil {
+		t.Fatalf("deflate failed: %v", err)
+	}
+	if len(got.P) != 1 {
+		t.Fatalf("expecting a single shape, got %d", len(got.P))
+	}
+	checkPoints(t, "deflate miter", got.P[0].PS, square(Point{1, 1}, Point{3, 3}))
+}
+
+func TestOffsetHole(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	ss = ss.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	if !ss.P[1].Hole {
+		t.Fatalf("expected second shape to be a hole")
+	}
+	got, err := ss.Offset(1, 0.5, JoinMiter, 4, 0.1)
+	if err != nil {
+		t.Fatalf("offset of hole failed: %v", err)
+	}
+	if !got.P[0].Hole {
+		t.Fatalf("offsetting a hole should still produce a hole")
+	}
+	// Inflating the solid shrinks the hole by 0.5 on each side. Holes
+	// are wound clockwise, starting at the leftmost-lowest point.
+	checkPoints(t, "hole offset", got.P[0].PS, []Point{
+		{4.5, 4.5}, {4.5, 5.5}, {5.5, 5.5}, {5.5, 4.5},
+	})
+}
+
+func TestOffsetRoundAndSquare(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{2, 2})...)
+	if got, err := ss.Offset(0, 1, JoinRound, 4, 0.1); err != nil {
+		t.Fatalf("round offset failed: %v", err)
+	} else if len(got.P[0].PS) <= len(square(Point{0, 0}, Point{2, 2})) {
+		t.Errorf("round join should sample additional points around each corner, got %d points", len(got.P[0].PS))
+	}
+	if got, err := ss.Offset(0, 1, JoinSquare, 4, 0.1); err != nil {
+		t.Fatalf("square offset failed: %v", err)
+	} else if len(got.P[0].PS) != 8 {
+		t.Errorf("square join should replace each corner with a two-point flat chamfer, got %d points: %v", len(got.P[0].PS), got.P[0].PS)
+	}
+}
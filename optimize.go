@@ -0,0 +1,178 @@
+package polygon
+
+import (
+	"math"
+	"time"
+)
+
+// OptimizeOptions controls the tour OptimizeLines builds over a set of
+// plotted lines.
+type OptimizeOptions struct {
+	// Start is the pen-up position the plotter head is assumed to
+	// already be at before the first line is drawn.
+	Start Point
+	// MaxPasses caps the number of 2-opt improvement passes. 0
+	// means unlimited: keep passing until a full pass finds no
+	// improving swap.
+	MaxPasses int
+	// TimeBudget caps the wall-clock time spent on 2-opt
+	// improvement passes. 0 means unlimited.
+	TimeBudget time.Duration
+}
+
+// leg is one line in a tour, oriented so entry/exit give the point the
+// plotter head is at before and after drawing it.
+type leg struct {
+	idx int
+	rev bool
+}
+
+func (lg leg) entry(lines []Line) Point {
+	if lg.rev {
+		return lines[lg.idx].To
+	}
+	return lines[lg.idx].From
+}
+
+func (lg leg) exit(lines []Line) Point {
+	if lg.rev {
+		return lines[lg.idx].From
+	}
+	return lines[lg.idx].To
+}
+
+func dist(a, b Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+// nearestNeighborTour greedily builds an initial tour over lines,
+// starting from start: at each step it picks whichever unvisited
+// line's nearer endpoint is closest to the current pen-up position,
+// travels through that line to its other end, and continues from
+// there.
+func nearestNeighborTour(lines []Line, start Point) []leg {
+	remaining := make([]int, len(lines))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	cur := start
+	tour := make([]leg, 0, len(lines))
+	for len(remaining) > 0 {
+		bestJ, bestRev, bestD := 0, false, math.Inf(1)
+		for j, idx := range remaining {
+			ln := lines[idx]
+			if d := dist(cur, ln.From); d < bestD {
+				bestD, bestJ, bestRev = d, j, false
+			}
+			if d := dist(cur, ln.To); d < bestD {
+				bestD, bestJ, bestRev = d, j, true
+			}
+		}
+		lg := leg{idx: remaining[bestJ], rev: bestRev}
+		tour = append(tour, lg)
+		cur = lg.exit(lines)
+		remaining = append(remaining[:bestJ], remaining[bestJ+1:]...)
+	}
+	return tour
+}
+
+// twoOpt repeatedly looks for a pair of non-adjacent legs whose
+// sub-path, reversed, shortens the tour's total pen-up travel,
+// applying each such reversal as soon as it finds it, until a pass
+// improves nothing or passes/budget runs out.
+func twoOpt(tour []leg, lines []Line, start Point, opts OptimizeOptions) {
+	var deadline time.Time
+	if opts.TimeBudget > 0 {
+		deadline = time.Now().Add(opts.TimeBudget)
+	}
+	// exitBefore(i) is the pen-up position after leg i (or start,
+	// for the virtual leg -1 that precedes the tour).
+	exitBefore := func(i int) Point {
+		if i < 0 {
+			return start
+		}
+		return tour[i].exit(lines)
+	}
+	entryAt := func(i int) Point {
+		return tour[i].entry(lines)
+	}
+	n := len(tour)
+	for pass := 0; opts.MaxPasses <= 0 || pass < opts.MaxPasses; pass++ {
+		improved := false
+		for i := -1; i < n-1; i++ {
+			if !deadline.IsZero() && time.Now().After(deadline) {
+				return
+			}
+			for j := i + 2; j < n; j++ {
+				if i == -1 && j == n-1 {
+					continue // reverses the whole tour: no change
+				}
+				// Reversing tour[i+1:j+1] replaces the edges
+				// into i+1 and out of j with edges into j
+				// (entered from its old exit, since its
+				// orientation flips) and out of i+1 (exited
+				// from its old entry, for the same reason).
+				before := dist(exitBefore(i), entryAt(i+1))
+				after := dist(exitBefore(i), exitBefore(j))
+				if j+1 < n {
+					before += dist(exitBefore(j), entryAt(j+1))
+					after += dist(entryAt(i+1), entryAt(j+1))
+				}
+				if after < before-Zeroish {
+					reverseSegment(tour, i+1, j)
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			return
+		}
+	}
+}
+
+// reverseSegment reverses tour[lo:hi+1] in place, flipping each leg's
+// orientation so it is still drawn start to end, just entered from
+// what used to be its exit point.
+func reverseSegment(tour []leg, lo, hi int) {
+	for a, b := lo, hi; a < b; a, b = a+1, b-1 {
+		tour[a], tour[b] = tour[b], tour[a]
+	}
+	for k := lo; k <= hi; k++ {
+		tour[k].rev = !tour[k].rev
+	}
+}
+
+// OptimizeLinesWith rearranges lines into the order (and, per line,
+// direction) that minimizes the plotter head's total pen-up travel,
+// starting from opts.Start: it builds an initial tour with a
+// nearest-neighbor greedy pass over the 2*len(lines) endpoints (each
+// line contributing a fixed, must-draw edge between its own two
+// endpoints), then repeatedly improves it with 2-opt, reversing each
+// non-adjacent sub-path whose reversal reduces total travel as soon as
+// it is found, until a pass finds no improvement or opts.MaxPasses/opts.TimeBudget
+// is reached. lines is reordered and, where flipped, its endpoints are
+// swapped in place.
+func OptimizeLinesWith(lines []Line, opts OptimizeOptions) {
+	if len(lines) < 2 {
+		return
+	}
+	tour := nearestNeighborTour(lines, opts.Start)
+	twoOpt(tour, lines, opts.Start, opts)
+	out := make([]Line, len(lines))
+	for i, lg := range tour {
+		ln := lines[lg.idx]
+		if lg.rev {
+			ln.From, ln.To = ln.To, ln.From
+		}
+		out[i] = ln
+	}
+	copy(lines, out)
+}
+
+// OptimizeLines rearranges the result of (*Shapes).[V]Slice() into
+// lines that can be plotted in a shorter time, using OptimizeLinesWith
+// with the default options (starting flight from the origin, running
+// 2-opt to convergence with no time limit).
+func OptimizeLines(lines []Line) {
+	OptimizeLinesWith(lines, OptimizeOptions{})
+}
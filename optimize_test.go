@@ -0,0 +1,67 @@
+package polygon
+
+import "testing"
+
+// tourLength totals the pen-up travel OptimizeLines(With) leaves
+// between consecutive lines, starting from start.
+func tourLength(start Point, lines []Line) float64 {
+	total := 0.0
+	cur := start
+	for _, ln := range lines {
+		total += dist(cur, ln.From)
+		cur = ln.To
+	}
+	return total
+}
+
+func TestOptimizeLinesReducesTravel(t *testing.T) {
+	// A zig-zag scanline order: adjacent lines in this list are far
+	// apart, even though a nearest-neighbor tour of the same lines
+	// is much shorter.
+	lines := []Line{
+		{From: Point{0, 0}, To: Point{1, 0}},
+		{From: Point{0, 10}, To: Point{1, 10}},
+		{From: Point{0, 2}, To: Point{1, 2}},
+		{From: Point{0, 8}, To: Point{1, 8}},
+		{From: Point{0, 4}, To: Point{1, 4}},
+		{From: Point{0, 6}, To: Point{1, 6}},
+	}
+	before := tourLength(Point{}, lines)
+	got := append([]Line{}, lines...)
+	OptimizeLines(got)
+	after := tourLength(Point{}, got)
+	if after >= before {
+		t.Errorf("OptimizeLines did not shorten travel: before=%v after=%v", before, after)
+	}
+	if len(got) != len(lines) {
+		t.Fatalf("OptimizeLines changed the line count: got %d, want %d", len(got), len(lines))
+	}
+}
+
+func TestOptimizeLinesWithRespectsStart(t *testing.T) {
+	lines := []Line{
+		{From: Point{10, 0}, To: Point{11, 0}},
+		{From: Point{0, 0}, To: Point{1, 0}},
+	}
+	OptimizeLinesWith(lines, OptimizeOptions{Start: Point{0, 0}})
+	if lines[0].From != (Point{0, 0}) {
+		t.Errorf("expected the line nearest Start to be drawn first, got %v", lines)
+	}
+}
+
+func TestOptimizeLinesWithMaxPassesStopsEarly(t *testing.T) {
+	lines := []Line{
+		{From: Point{0, 0}, To: Point{1, 0}},
+		{From: Point{0, 5}, To: Point{1, 5}},
+		{From: Point{0, 1}, To: Point{1, 1}},
+		{From: Point{0, 4}, To: Point{1, 4}},
+	}
+	// MaxPasses: 0 means unlimited; confirm a single pass still
+	// produces a valid permutation of the same lines without
+	// error (the precise route isn't asserted, just that the
+	// budget is honored without crashing or dropping lines).
+	OptimizeLinesWith(lines, OptimizeOptions{MaxPasses: 1})
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines to survive, got %d", len(lines))
+	}
+}
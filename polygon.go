@@ -110,6 +110,43 @@ type Shapes struct {
 	index int
 	// P holds the polygon Shape data.
 	P []*Shape
+	// tree caches an Rtree over the bounding boxes of P, built on
+	// demand by Index and invalidated (set to nil) by anything
+	// that mutates P.
+	tree *Rtree
+}
+
+// invalidateTree drops any cached Rtree, so the next call to Index
+// rebuilds it from the current P.
+func (p *Shapes) invalidateTree() {
+	if p != nil {
+		p.tree = nil
+	}
+}
+
+// Index returns an Rtree over the bounding boxes of p.P, indexed by
+// position in P, building (or rebuilding, if P has been mutated since
+// the last call) it lazily. Callers that want to run their own
+// spatial queries over the shapes can call (*Rtree).Query on its
+// result with any Box, which is all (*Shapes).Query does.
+func (p *Shapes) Index() *Rtree {
+	if p == nil {
+		return nil
+	}
+	if p.tree == nil {
+		boxes := make([]Box, len(p.P))
+		for i, s := range p.P {
+			boxes[i] = Box{MinX: s.MinX, MinY: s.MinY, MaxX: s.MaxX, MaxY: s.MaxY}
+		}
+		p.tree = newRtree(boxes, rtreeLeafSize)
+	}
+	return p.tree
+}
+
+// Query returns the indices into p.P of every shape whose bounding
+// box overlaps q.
+func (p *Shapes) Query(q Box) []int {
+	return p.Index().Query(q)
 }
 
 // Debug generates a text dump to os.Stdout of the shapes in a
@@ -214,6 +251,7 @@ func (p *Shapes) Append(pts ...Point) (*Shapes, error) {
 	p.index++
 	poly.Index = fmt.Sprint(p.index)
 	p.P = append(p.P, poly)
+	p.invalidateTree()
 	return p, nil
 }
 
@@ -240,6 +278,7 @@ func (p *Shapes) Include(s ...*Shape) *Shapes {
 		p = &Shapes{}
 	}
 	p.P = append(p.P, s...)
+	p.invalidateTree()
 	return p
 }
 
@@ -279,26 +318,12 @@ func (p *Shapes) Builder(pts ...Point) *Shapes {
 // Transform returns a rotated Shapes structure, p is rotated by theta
 // radians (+ve = counterclockwise) around a fixed Point, pt, and
 // scales the rotated shape by a factor of scale. The scaled and
-// rotated shape is then translated from pt to to.
+// rotated shape is then translated from pt to to. This is a thin
+// wrapper around the equivalent Matrix built from Translate, Rotate
+// and Scale; use Apply directly for more general affine transforms.
 func (p *Shapes) Transform(at, to Point, theta, scale float64) *Shapes {
-	if p == nil {
-		return nil
-	}
-	var sh *Shapes
-	s := math.Sin(theta) * scale
-	c := math.Cos(theta) * scale
-	for _, v := range p.P {
-		var pts []Point
-		for _, pt := range v.PS {
-			dX, dY := pt.X-at.X, pt.Y-at.Y
-			pts = append(pts, Point{
-				X: to.X + c*dX - s*dY,
-				Y: to.Y + s*dX + c*dY,
-			})
-		}
-		sh = sh.Builder(pts...)
-	}
-	return sh
+	m := Translate(-at.X, -at.Y).Mul(Rotate(theta)).Mul(Scale(scale, scale)).Mul(Translate(to.X, to.Y))
+	return p.Apply(m)
 }
 
 // Duplicate duplicates a single polygon, s.
@@ -364,10 +389,7 @@ func (a Point) Unit(b Point) (u Point, err error) {
 
 // moreClockwise confirms that c is more clockwise than d from b.
 func moreClockwise(b, c, d Point) bool {
-	bc := c.AddX(b, -1)
-	bd := d.AddX(b, -1)
-	crossBCBD := bc.X*bd.Y - bc.Y*bd.X
-	return crossBCBD >= 0
+	return orient2DSign(b, c, d) >= 0
 }
 
 // isLeft determines if point a is left of the line segment (b->c). By
@@ -523,7 +545,14 @@ func intersect(a, b, c, d Point) (hit bool, left, hold bool, at Point) {
 		bb0.Y -= Zeroish / 2
 		bb1.Y += Zeroish / 2
 	}
-	if r := dABX*dCDY - dABY*dCDX; math.Abs(r) > Zeroish2 {
+	// r is cross(AB, CD): zero exactly when the two lines are
+	// (anti)parallel. orient2DSign(a, b, x) is cross(b-a, x-a), so
+	// cross(AB, CD) = cross(AB, D-a) - cross(AB, C-a) is the
+	// difference of two orient2DSign calls, which - unlike the plain
+	// dABX*dCDY-dABY*dCDX subtraction this replaced - resolves each
+	// term itself with the current PredicateMode's precision instead
+	// of only ever comparing plain float64 products.
+	if r := orient2DSign(a, b, d) - orient2DSign(a, b, c); math.Abs(r) > Zeroish2 {
 		if math.Abs(dCDX) > Zeroish && math.Abs(dABX) < Zeroish {
 			at.X = a.X
 			mCD := dCDY / dCDX
@@ -551,8 +580,11 @@ func intersect(a, b, c, d Point) (hit bool, left, hold bool, at Point) {
 		hit = !(bb0.X > at.X || bb1.X < at.X || bb0.Y > at.Y || bb1.Y < at.Y)
 		return
 	}
-	// The lines are (anti)parallel
-	if closeness := (a.Y-d.Y)*dABX - (a.X-d.X)*dABY; math.Abs(closeness) > Zeroish2 {
+	// The lines are (anti)parallel: d is collinear with (a->b) exactly
+	// when orient2DSign(a, b, d) (cross(AB, AD)) is zero, so this reuses
+	// the same precision-aware predicate rather than a bespoke float64
+	// cross product.
+	if closeness := orient2DSign(a, b, d); math.Abs(closeness) > Zeroish2 {
 		return // parallel but not collinear.
 	}
 
@@ -639,7 +671,14 @@ func (a Point) prunedInside(p *Shape, skip map[Point]bool) bool {
 		if skip != nil && skip[next] {
 			continue
 		}
-		if hit, _, _, _ := intersect(a, to, prev, next); hit {
+		// An edge whose Y-interval doesn't bracket a.Y cannot
+		// cross the horizontal ray (a->to), so skip the
+		// (relatively expensive) intersect() call for it.
+		hit := false
+		if (prev.Y-Zeroish <= a.Y && a.Y <= next.Y+Zeroish) || (next.Y-Zeroish <= a.Y && a.Y <= prev.Y+Zeroish) {
+			hit, _, _, _ = intersect(a, to, prev, next)
+		}
+		if hit {
 			is := 0
 			if next.Y > prev.Y+Zeroish {
 				is = 1
@@ -695,9 +734,61 @@ func (p *Shape) Hull() (hull *Shape, contained map[Point]bool) {
 	return
 }
 
+// onEdge is one crossing point landing on a ring edge, recorded with
+// its signed distance along that edge so insertHits can order several
+// hits on the same edge correctly.
+type onEdge struct {
+	at   Point
+	dist float64
+}
+
+// insertHits returns pts with, for every original edge index present
+// in hits, that edge's crossing points inserted in edge-order,
+// skipping any point that already matches one of the edge's own
+// endpoints or the point just inserted before it - an edge's two
+// endpoints can each independently land a hit against the same point
+// on the other shape (e.g. two edges sharing a vertex that sits
+// exactly on the other ring), which would otherwise insert it twice.
+func insertHits(pts []Point, hits map[int][]onEdge) []Point {
+	n := len(pts)
+	out := make([]Point, 0, n)
+	for i, p := range pts {
+		out = append(out, p)
+		edge := hits[i]
+		if len(edge) == 0 {
+			continue
+		}
+		sort.Slice(edge, func(x, y int) bool { return edge[x].dist < edge[y].dist })
+		b := pts[(i+1)%n]
+		for _, h := range edge {
+			if MatchPoint(h.at, p, b, out[len(out)-1]) {
+				continue
+			}
+			out = append(out, h.at)
+		}
+	}
+	return out
+}
+
 // crossings evaluates p1 and p2 for common points of intersection. It
 // returns n1 and n2 as the same shapes but with all of the hit points
 // inserted into both shapes.
+//
+// Finding the crossings themselves is a single sweepSegmentPairs pass
+// (the Bentley–Ottmann plane sweep in sweep.go) over n1's and n2's
+// edges combined into one segs slice, with a skip predicate that
+// rejects any pair drawn from the same side - those are two edges of
+// the same ring, which this function assumes is already simple, not a
+// crossing of interest. That replaces the Rtree-pruned pairwise scan
+// this function used before, which was O(|n1|·|n2|) in the worst case.
+//
+// Recording the hits is kept as a separate pass: each crossing is
+// filed under its n1 edge and its n2 edge (onEdge, with distance along
+// the edge for ordering), and insertHits splices every edge's hits
+// into a fresh copy of that ring in one pass over the original,
+// unmutated points. This avoids the previous version's incremental
+// splice-and-rescan of n1.PS/n2.PS mid-sweep, which had to track how
+// original n2 edge indices shifted as points were spliced in.
 func crossings(p1, p2 *Shape) (hits map[Point]bool, n1, n2 *Shape) {
 	var err error
 	n1, err = p1.dissolve()
@@ -709,57 +800,55 @@ func crossings(p1, p2 *Shape) (hits map[Point]bool, n1, n2 *Shape) {
 		log.Fatalf("p2=%v dissolves to %v: %v", p2, n2, err)
 	}
 	hits = make(map[Point]bool)
-	for i := 0; i < len(n1.PS); i++ {
-		a := n1.PS[i]
-		b := n1.PS[(i+1)%len(n1.PS)]
-		for j := 0; j < len(n2.PS); j++ {
-			c := n2.PS[j]
-			d := n2.PS[(j+1)%len(n2.PS)]
-			// Close but not equal is a source of
-			// problems, so given a close match treat a as
-			// the anchor point and move c and/or d to it.
-			if MatchPoint(a, c) && a.NotSame(c) {
-				n2.PS[j] = a
-				c = a
-			}
-			if MatchPoint(a, d) && a.NotSame(d) {
-				n2.PS[(j+1)%len(n2.PS)] = a
-				d = a
-			}
-			hit, _, _, e := intersect(a, b, c, d)
-			if hit {
-				// Prefer canonical points vs derived ones.
-				// Above we've confirmed that a != b.
-				if MatchPoint(e, a) && e.NotSame(a) {
-					e = a
-				} else if MatchPoint(e, b) && e.NotSame(b) {
-					e = b
-				}
-				// For this polygon we nudge the
-				// points themselves. This is needed to
-				// make use of the hits map later.
-				if MatchPoint(e, c) && e.NotSame(c) {
-					c = e
-					n2.PS[j] = e
-				} else if MatchPoint(e, d) && e.NotSame(d) {
-					d = e
-					n2.PS[(j+1)%len(n2.PS)] = e
-				}
-				hits[e] = true
-				if !MatchPoint(e, c, d) {
-					tmp := append([]Point{e}, n2.PS[j+1:]...)
-					n2.PS = append(n2.PS[:j+1], tmp...)
-					// possible the next intersection will be "before" this hit.
-					j--
-				}
-				if !MatchPoint(e, a, b) {
-					tmp := append([]Point{e}, n1.PS[i+1:]...)
-					n1.PS = append(n1.PS[:i+1], tmp...)
-					b = e
-				}
-			}
+
+	n, m := len(n1.PS), len(n2.PS)
+	segs := make([]segIdx, 0, n+m)
+	mkSeg := func(a, b Point, i int) segIdx {
+		s := segIdx{a: a, b: b, i: i}
+		if a.X > b.X || (a.X == b.X && a.Y > b.Y) {
+			s.a, s.b = b, a
 		}
+		return s
+	}
+	for i := 0; i < n; i++ {
+		segs = append(segs, mkSeg(n1.PS[i], n1.PS[(i+1)%n], i))
 	}
+	for j := 0; j < m; j++ {
+		segs = append(segs, mkSeg(n2.PS[j], n2.PS[(j+1)%m], n+j))
+	}
+	crossHits := sweepSegmentPairs(segs, func(i, j int) bool {
+		return (segs[i].i < n) == (segs[j].i < n)
+	})
+
+	edge1 := make(map[int][]onEdge)
+	edge2 := make(map[int][]onEdge)
+	for _, h := range crossHits {
+		i1, j2 := h.I, h.J
+		if i1 >= n {
+			i1, j2 = j2, i1
+		}
+		j2 -= n
+		a, b := n1.PS[i1], n1.PS[(i1+1)%n]
+		c, d := n2.PS[j2], n2.PS[(j2+1)%m]
+		// Prefer a canonical endpoint over the sweep's computed
+		// point when they're close enough to be the same vertex.
+		at := h.At
+		switch {
+		case MatchPoint(at, a) && at.NotSame(a):
+			at = a
+		case MatchPoint(at, b) && at.NotSame(b):
+			at = b
+		case MatchPoint(at, c) && at.NotSame(c):
+			at = c
+		case MatchPoint(at, d) && at.NotSame(d):
+			at = d
+		}
+		hits[at] = true
+		edge1[i1] = append(edge1[i1], onEdge{at: at, dist: at.AddX(a, -1).Dot(b.AddX(a, -1))})
+		edge2[j2] = append(edge2[j2], onEdge{at: at, dist: at.AddX(c, -1).Dot(d.AddX(c, -1))})
+	}
+	n1.PS = insertHits(n1.PS, edge1)
+	n2.PS = insertHits(n2.PS, edge2)
 	return
 }
 
@@ -955,6 +1044,7 @@ func (p *Shapes) combine(n, m int) (banked int) {
 		// Drop invalid Shape (one that can't be rationalized).
 		banked = m
 		p.P = append(p.P[:m], p.P[m+1:]...)
+		p.invalidateTree()
 		return
 	}
 	banked = m + 1
@@ -972,12 +1062,14 @@ func (p *Shapes) combine(n, m int) (banked int) {
 	if i2 {
 		p1.Index = fmt.Sprint("(", p1.Index, "!", p2.Index, ")")
 		p.P = append(p.P[:m], p.P[m+1:]...)
+		p.invalidateTree()
 		banked = m
 		return
 	}
 	if i1 {
 		p2.Index = fmt.Sprint("(", p2.Index, "!", p1.Index, ")")
 		p.P = append(p.P[:n], p.P[n+1:]...)
+		p.invalidateTree()
 		banked = n + 1
 		return
 	}
@@ -1005,6 +1097,7 @@ func (p *Shapes) combine(n, m int) (banked int) {
 	rest := append([]*Shape{}, p.P[m+1:]...)
 	next := append(polys.P, p.P[n+1:m]...)
 	p.P = append(append(p.P[:n], next...), rest...)
+	p.invalidateTree()
 
 	// Return pointing to the first of the polys Holes (if any).
 	banked = n + 1
@@ -1058,11 +1151,21 @@ func (p *Shapes) Add(s *Shapes) *Shapes {
 // this hole. The hole and its content are placed at the end of the
 // p.P array.
 func (p *Shapes) trimHole(i int, ref, holed *Shapes) (int, *Shapes) {
+	p.invalidateTree()
 	islands := false
-	for j := i + 1; j < len(ref.P); j++ {
+	self := p.P[i]
+	candidates := ref.Query(Box{MinX: self.MinX, MinY: self.MinY, MaxX: self.MaxX, MaxY: self.MaxY})
+	sort.Ints(candidates)
+	for _, j := range candidates {
+		if j <= i {
+			// Only shapes ordered after i in ref (see Reorder)
+			// still need trimming against this hole.
+			continue
+		}
 		p1, p2 := p.P[i], ref.P[j]
 		if p1.MinX > p2.MaxX || p1.MaxX < p2.MinX || p1.MinY > p2.MaxY || p1.MaxY < p2.MinY {
-			// Bounding boxes do not overlap.
+			// Rtree.Query is a candidate filter against merged
+			// leaf boxes, so re-check the exact overlap.
 			continue
 		}
 		hits, p1, p2 := crossings(p1, p2)
@@ -1160,6 +1263,13 @@ func (p *Shapes) trimHole(i int, ref, holed *Shapes) (int, *Shapes) {
 // the wrong thing. The outline shapes and holes contain only summary
 // information that may be insufficient to use for subsequent union
 // operations.
+//
+// The hole-vs-shape scan in trimHole is driven off ref, an untouched
+// snapshot of p, so its Rtree (see Index) can be built once and reused
+// for every hole. p.P itself is mutated continuously by combine
+// (shapes merge, grow, and get removed), so a bulk-loaded tree over it
+// would go stale after the first merge; the non-hole pairwise loop
+// below is left as an all-pairs scan for that reason.
 func (p *Shapes) Union() {
 	if p == nil || len(p.P) < 2 {
 		return
@@ -1225,6 +1335,21 @@ func (p *Shapes) Inflate(n int, d float64) error {
 	return nil
 }
 
+// holeIndex bulk-loads an Rtree over the bounding boxes of p.P[hi] for
+// hi in holeI, so Slice/VSlice can narrow the holes they recheck a
+// cut line against down to the ones whose bounding box actually
+// crosses the current scanline band, instead of rechecking every
+// named hole on every line. The Rtree's candidate indices are
+// positions into holeI, not into p.P.
+func (p *Shapes) holeIndex(holeI []int) *Rtree {
+	boxes := make([]Box, len(holeI))
+	for k, hi := range holeI {
+		hole := p.P[hi]
+		boxes[k] = Box{MinX: hole.MinX, MinY: hole.MinY, MaxX: hole.MaxX, MaxY: hole.MaxY}
+	}
+	return newRtree(boxes, rtreeLeafSize)
+}
+
 // Slice returns an array of horizontal (dy=0) lines to render the
 // filled polygon. This can be used to rasterize a shape in some
 // output format. The radial width of a rendered line is d. The lines
@@ -1250,6 +1375,7 @@ func (p *Shapes) Slice(i int, d float64, holeI ...int) (lines []Line, err error)
 	}
 	// X range guaranteed to extend outside of polygon.
 	left, right := s.MinX-d, s.MaxX+d
+	holeTree := p.holeIndex(holeI)
 	for level := bottom + half; level < top; level += half {
 		var a, b Point
 		nudge := 0.0
@@ -1299,8 +1425,9 @@ func (p *Shapes) Slice(i int, d float64, holeI ...int) (lines []Line, err error)
 			// broken by a hole into two, or do not
 			// overlap at all.
 			var hits []float64
-			for _, hi := range holeI {
-				hole := p.P[hi]
+			band := Box{MinX: line.From.X, MinY: level, MaxX: line.To.X, MaxY: level}
+			for _, hk := range holeTree.Query(band) {
+				hole := p.P[holeI[hk]]
 				if hole.MaxY < level || hole.MinY > level || hole.MinX > line.To.X || hole.MaxX < line.From.X {
 					continue
 				}
@@ -1355,6 +1482,7 @@ func (p *Shapes) VSlice(i int, d float64, holeI ...int) (lines []Line, err error
 	}
 	// Y range guaranteed to extend outside of polygon.
 	below, above := s.MinY-half, s.MaxY+half
+	holeTree := p.holeIndex(holeI)
 	for level := left + half; level < right; level += half {
 		var a, b Point
 		nudge := 0.0
@@ -1404,8 +1532,9 @@ func (p *Shapes) VSlice(i int, d float64, holeI ...int) (lines []Line, err error
 			// broken by a hole into two, or do not
 			// overlap at all.
 			var hits []float64
-			for _, hi := range holeI {
-				hole := p.P[hi]
+			band := Box{MinX: level, MinY: line.From.Y, MaxX: level, MaxY: line.To.Y}
+			for _, hk := range holeTree.Query(band) {
+				hole := p.P[holeI[hk]]
 				if hole.MaxX < level || hole.MinX > level || hole.MinY > line.To.Y || hole.MaxY < line.From.Y {
 					continue
 				}
@@ -1439,25 +1568,3 @@ func (p *Shapes) VSlice(i int, d float64, holeI ...int) (lines []Line, err error
 	}
 	return
 }
-
-// OptimizeLines rearranges the result of (*Shapes).[V]Slice() into
-// lines that can be plotted in a shorter time. It works by reordering
-// consecutive lines when that minimizes the flight time of the
-// plotter head between lines.
-func OptimizeLines(lines []Line) {
-	var last Point
-	for i, line := range lines {
-		dF := line.From.AddX(last, -1)
-		dT := line.To.AddX(last, -1)
-		cf := dT.Dot(dT) - dF.Dot(dF)
-		if cf < 0 {
-			lines[i] = Line{
-				From: line.To,
-				To:   line.From,
-			}
-			last = line.From
-		} else {
-			last = line.To
-		}
-	}
-}
@@ -0,0 +1,62 @@
+package polygon
+
+import "github.com/tinkerator/polygon/internal/predicates"
+
+// PredicateMode selects how this package resolves the orientation
+// sign tests (isLeft, moreClockwise) that intersect and the Union
+// tracer depend on; the hole/winding classification in Rationalize
+// uses a plain float64 cross product whatever the mode. It lets a caller trade the speed of plain float64
+// subtraction against Shewchuk-style adaptive-precision predicates
+// that can't be fooled by near-parallel or near-coincident edges.
+type PredicateMode int
+
+const (
+	// Fast computes sign tests with plain float64 subtraction, the
+	// behaviour this package has always had. This is the default,
+	// since it is what every existing Shapes pipeline has been
+	// measured and tuned against.
+	Fast PredicateMode = iota
+	// Adaptive tries the same fast float64 path, but falls back to
+	// exact expansion arithmetic whenever the fast result is too
+	// close to zero for its own rounding error to rule out a sign
+	// flip. Correct and, away from degenerate inputs, nearly as
+	// fast as Fast.
+	Adaptive
+	// Exact always computes sign tests with expansion arithmetic,
+	// skipping the fast path's error-bound check entirely. Slower
+	// than Adaptive; useful when debugging a suspected predicate
+	// disagreement rather than on a hot path.
+	Exact
+)
+
+// predicateMode is the process-wide PredicateMode used by
+// moreClockwise (and so by isLeft, intersect's left/hold results, and
+// anything else built on them). It is a package variable, not a field
+// on Shapes, because orientation sign tests are called from free
+// functions (intersect, moreClockwise itself) that don't carry a
+// *Shapes receiver.
+var predicateMode = Fast
+
+// SetPredicateMode sets the PredicateMode used by this package's
+// orientation sign tests from then on. It is not safe to call
+// concurrently with geometry operations.
+func SetPredicateMode(mode PredicateMode) {
+	predicateMode = mode
+}
+
+// orient2DSign returns a value whose sign matches the exact
+// orientation of (b, c, d) - positive if counterclockwise, negative if
+// clockwise, zero if collinear - computed according to the current
+// PredicateMode.
+func orient2DSign(b, c, d Point) float64 {
+	switch predicateMode {
+	case Exact:
+		return predicates.Orient2DExact(c.X, c.Y, d.X, d.Y, b.X, b.Y)
+	case Adaptive:
+		return predicates.Orient2D(c.X, c.Y, d.X, d.Y, b.X, b.Y)
+	default:
+		bc := c.AddX(b, -1)
+		bd := d.AddX(b, -1)
+		return bc.X*bd.Y - bc.Y*bd.X
+	}
+}
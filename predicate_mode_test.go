@@ -0,0 +1,40 @@
+package polygon
+
+import "testing"
+
+func TestSetPredicateModeAdaptiveAgreesWithFast(t *testing.T) {
+	var a, b *Shapes
+	a = a.Builder(square(Point{0, 0}, Point{2, 2})...)
+	b = b.Builder(square(Point{1, 1}, Point{3, 3})...)
+
+	SetPredicateMode(Fast)
+	want, err := a.Intersect(b)
+	if err != nil {
+		t.Fatalf("Fast Intersect: %v", err)
+	}
+
+	SetPredicateMode(Adaptive)
+	defer SetPredicateMode(Fast)
+	got, err := a.Intersect(b)
+	if err != nil {
+		t.Fatalf("Adaptive Intersect: %v", err)
+	}
+
+	if len(got.P) != len(want.P) {
+		t.Fatalf("Adaptive Intersect: got %d shapes, want %d", len(got.P), len(want.P))
+	}
+	for i := range want.P {
+		checkPoints(t, "Adaptive Intersect", got.P[i].PS, want.P[i].PS)
+	}
+}
+
+func TestMoreClockwiseAgreesAcrossModes(t *testing.T) {
+	b, c, d := Point{0, 0}, Point{1, 0}, Point{0, 1}
+	for _, mode := range []PredicateMode{Fast, Adaptive, Exact} {
+		SetPredicateMode(mode)
+		if !moreClockwise(b, c, d) {
+			t.Errorf("mode=%v: moreClockwise(%v,%v,%v) = false, want true", mode, b, c, d)
+		}
+	}
+	SetPredicateMode(Fast)
+}
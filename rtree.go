@@ -0,0 +1,168 @@
+package polygon
+
+import (
+	"math"
+	"sort"
+)
+
+// rtreeLeafSize is the default number of items packed into each leaf
+// of a bulk-loaded Rtree.
+const rtreeLeafSize = 16
+
+// Box is an axis-aligned bounding box, used both to index items in an
+// Rtree and as the query argument to (*Shapes).Query.
+type Box struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// unionBox returns the smallest box containing both a and b.
+func unionBox(a, b Box) Box {
+	return Box{
+		MinX: min(a.MinX, b.MinX),
+		MinY: min(a.MinY, b.MinY),
+		MaxX: max(a.MaxX, b.MaxX),
+		MaxY: max(a.MaxY, b.MaxY),
+	}
+}
+
+// overlaps confirms that a and b share some common area (or edge).
+func (a Box) overlaps(b Box) bool {
+	return a.MinX <= b.MaxX && b.MinX <= a.MaxX && a.MinY <= b.MaxY && b.MinY <= a.MaxY
+}
+
+// edgeBox returns the bounding box of the line segment a->b, padded
+// by Zeroish so it matches the tolerance intersect() itself applies
+// when rejecting non-overlapping segments.
+func edgeBox(a, b Point) Box {
+	ll, tr := BB(a, b)
+	return Box{MinX: ll.X - Zeroish, MinY: ll.Y - Zeroish, MaxX: tr.X + Zeroish, MaxY: tr.Y + Zeroish}
+}
+
+// rtreeNode is either an internal node (kids set) or a leaf (items
+// set), with box always the union of whatever it holds.
+type rtreeNode struct {
+	box   Box
+	kids  []*rtreeNode
+	items []int
+}
+
+// Rtree is a static, bulk-loaded spatial index over a set of boxes,
+// queried by the original slice index of whichever boxes it holds.
+// It is built once (see newRtree) and never mutated.
+type Rtree struct {
+	root *rtreeNode
+}
+
+// strPack groups idx (indices into boxes) into leaves using the
+// sort-tile-recursive method: idx is sliced into roughly
+// sqrt(ceil(len/leafSize)) vertical strips by box centroid X, each strip is sorted by centroid
+// Y, and then chopped into leaves of leafSize items.
+func strPack(boxes []Box, idx []int, leafSize int) []*rtreeNode {
+	n := len(idx)
+	leafCount := (n + leafSize - 1) / leafSize
+	sliceCount := int(math.Ceil(math.Sqrt(float64(leafCount))))
+	if sliceCount < 1 {
+		sliceCount = 1
+	}
+	itemsPerSlice := sliceCount * leafSize
+
+	sorted := append([]int{}, idx...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return centroidX(boxes[sorted[i]]) < centroidX(boxes[sorted[j]])
+	})
+
+	var leaves []*rtreeNode
+	for s := 0; s < n; s += itemsPerSlice {
+		e := s + itemsPerSlice
+		if e > n {
+			e = n
+		}
+		strip := append([]int{}, sorted[s:e]...)
+		sort.Slice(strip, func(i, j int) bool {
+			return centroidY(boxes[strip[i]]) < centroidY(boxes[strip[j]])
+		})
+		for k := 0; k < len(strip); k += leafSize {
+			kk := k + leafSize
+			if kk > len(strip) {
+				kk = len(strip)
+			}
+			group := strip[k:kk]
+			leaf := &rtreeNode{items: append([]int{}, group...), box: boxes[group[0]]}
+			for _, gi := range group[1:] {
+				leaf.box = unionBox(leaf.box, boxes[gi])
+			}
+			leaves = append(leaves, leaf)
+		}
+	}
+	return leaves
+}
+
+// strPackNodes applies the same STR grouping as strPack, one level up
+// the tree, treating each node's own box as the item being packed.
+func strPackNodes(nodes []*rtreeNode, fanout int) []*rtreeNode {
+	boxes := make([]Box, len(nodes))
+	idx := make([]int, len(nodes))
+	for i, n := range nodes {
+		boxes[i] = n.box
+		idx[i] = i
+	}
+	var parents []*rtreeNode
+	for _, leaf := range strPack(boxes, idx, fanout) {
+		p := &rtreeNode{box: leaf.box}
+		for _, i := range leaf.items {
+			p.kids = append(p.kids, nodes[i])
+		}
+		parents = append(parents, p)
+	}
+	return parents
+}
+
+// centroidX and centroidY are the sort keys used to tile boxes during
+// bulk loading.
+func centroidX(b Box) float64 { return (b.MinX + b.MaxX) / 2 }
+func centroidY(b Box) float64 { return (b.MinY + b.MaxY) / 2 }
+
+// newRtree bulk-loads an Rtree over boxes, leafSize items per leaf
+// (rtreeLeafSize is used if leafSize is not positive). The returned
+// tree answers Query() with the original indices into boxes.
+func newRtree(boxes []Box, leafSize int) *Rtree {
+	if leafSize <= 0 {
+		leafSize = rtreeLeafSize
+	}
+	if len(boxes) == 0 {
+		return &Rtree{}
+	}
+	idx := make([]int, len(boxes))
+	for i := range idx {
+		idx[i] = i
+	}
+	nodes := strPack(boxes, idx, leafSize)
+	for len(nodes) > 1 {
+		nodes = strPackNodes(nodes, leafSize)
+	}
+	return &Rtree{root: nodes[0]}
+}
+
+// Query returns the indices of every box passed to newRtree whose box
+// overlaps q.
+func (t *Rtree) Query(q Box) []int {
+	if t == nil || t.root == nil {
+		return nil
+	}
+	var out []int
+	var walk func(n *rtreeNode)
+	walk = func(n *rtreeNode) {
+		if !n.box.overlaps(q) {
+			return
+		}
+		if n.items != nil {
+			out = append(out, n.items...)
+			return
+		}
+		for _, kid := range n.kids {
+			walk(kid)
+		}
+	}
+	walk(t.root)
+	return out
+}
@@ -0,0 +1,130 @@
+package polygon
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func bruteQuery(boxes []Box, q Box) []int {
+	var out []int
+	for i, b := range boxes {
+		if b.overlaps(q) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+func sortedInts(a []int) []int {
+	a = append([]int{}, a...)
+	sort.Ints(a)
+	return a
+}
+
+// TestRtreeQueryMatchesBruteForce confirms Query never misses a true
+// overlap. Query is a candidate filter, so it may also return boxes
+// whose own bounds don't overlap q (their leaf's merged bounds do);
+// callers are expected to re-check candidates exactly, as crossings
+// does via intersect().
+func TestRtreeQueryMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var boxes []Box
+	for i := 0; i < 500; i++ {
+		x, y := rng.Float64()*100, rng.Float64()*100
+		boxes = append(boxes, Box{MinX: x, MinY: y, MaxX: x + rng.Float64()*5, MaxY: y + rng.Float64()*5})
+	}
+	tree := newRtree(boxes, 16)
+	for i := 0; i < 50; i++ {
+		x, y := rng.Float64()*100, rng.Float64()*100
+		q := Box{MinX: x, MinY: y, MaxX: x + rng.Float64()*5, MaxY: y + rng.Float64()*5}
+		got := make(map[int]bool)
+		for _, k := range tree.Query(q) {
+			got[k] = true
+		}
+		for _, w := range sortedInts(bruteQuery(boxes, q)) {
+			if !got[w] {
+				t.Errorf("query %d: missed true overlap %d %v against %v", i, w, boxes[w], q)
+			}
+		}
+	}
+}
+
+func TestRtreeEmpty(t *testing.T) {
+	tree := newRtree(nil, 16)
+	if got := tree.Query(Box{MaxX: 1, MaxY: 1}); got != nil {
+		t.Errorf("expected no hits from an empty Rtree, got %v", got)
+	}
+}
+
+// randomRects scatters n axis-aligned unit squares, jittered so that
+// most pairs do not overlap but a realistic minority do.
+func randomRects(rng *rand.Rand, n int) []Point {
+	var pts []Point
+	for i := 0; i < n; i++ {
+		x, y := rng.Float64()*float64(n), rng.Float64()*float64(n)
+		pts = append(pts, square(Point{x, y}, Point{x + 1, y + 1})...)
+	}
+	return pts
+}
+
+// TestShapesQueryFindsOverlappingShapes confirms (*Shapes).Query
+// reports every shape whose bounding box overlaps the query Box,
+// against a large enough (and thus multi-leaf) set of shapes that a
+// distant, non-overlapping one actually gets pruned rather than just
+// inherited into a shared leaf box.
+func TestShapesQueryFindsOverlappingShapes(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	var ss *Shapes
+	var boxes []Box
+	for i := 0; i < 200; i++ {
+		x, y := rng.Float64()*100, rng.Float64()*100
+		ss = ss.Builder(square(Point{x, y}, Point{x + 1, y + 1})...)
+		boxes = append(boxes, Box{MinX: x, MinY: y, MaxX: x + 1, MaxY: y + 1})
+	}
+	q := Box{MinX: 40, MinY: 40, MaxX: 50, MaxY: 50}
+	got := make(map[int]bool)
+	for _, k := range ss.Query(q) {
+		got[k] = true
+	}
+	for i, b := range boxes {
+		if b.overlaps(q) && !got[i] {
+			t.Errorf("Query missed shape %d (%v) overlapping %v", i, b, q)
+		}
+	}
+}
+
+// TestShapesIndexRebuildsAfterMutation confirms the cached Rtree
+// returned by Index reflects the current P, not a stale snapshot from
+// before a Builder call added another shape.
+func TestShapesIndexRebuildsAfterMutation(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{1, 1})...)
+	if got := ss.Query(Box{MinX: 50, MinY: 50, MaxX: 51, MaxY: 51}); len(got) != 0 {
+		t.Fatalf("expected no hits before the second shape is added, got %v", got)
+	}
+	ss = ss.Builder(square(Point{50, 50}, Point{51, 51})...)
+	got := ss.Query(Box{MinX: 50, MinY: 50, MaxX: 51, MaxY: 51})
+	found := false
+	for _, k := range got {
+		found = found || k == 1
+	}
+	if !found {
+		t.Errorf("expected the newly added shape (index 1) among query results, got %v", got)
+	}
+}
+
+func BenchmarkUnionRandomRects(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	pts := randomRects(rng, 1000)
+	for i := 0; i < b.N; i++ {
+		shapes := &Shapes{}
+		for j := 0; j < len(pts); j += 4 {
+			var err error
+			if shapes, err = shapes.Append(pts[j : j+4]...); err != nil {
+				b.Fatalf("append: %v", err)
+			}
+		}
+		shapes.Union()
+	}
+}
@@ -0,0 +1,130 @@
+package polygon
+
+import (
+	"fmt"
+	"math"
+)
+
+// perpDist returns the perpendicular distance from v to the line
+// through a and b. If a and b coincide, it falls back to the
+// distance between v and a.
+func perpDist(a, b, v Point) float64 {
+	u, err := a.Unit(b)
+	if err != nil {
+		d := v.AddX(a, -1)
+		return math.Sqrt(d.Dot(d))
+	}
+	w := v.AddX(a, -1)
+	perp := w.AddX(u, -w.Dot(u))
+	return math.Sqrt(perp.Dot(perp))
+}
+
+// farthestPair returns the indices of the two points in pts with the
+// greatest separation.
+func farthestPair(pts []Point) (i, j int) {
+	best := -1.0
+	for a := 0; a < len(pts); a++ {
+		for b := a + 1; b < len(pts); b++ {
+			d := pts[a].AddX(pts[b], -1)
+			if d2 := d.Dot(d); d2 > best {
+				best, i, j = d2, a, b
+			}
+		}
+	}
+	return
+}
+
+// arcBetween returns the points of the ring pts walking forward
+// (wrapping around) from index i to index j, inclusive of both ends.
+func arcBetween(pts []Point, i, j int) []Point {
+	n := len(pts)
+	arc := []Point{pts[i]}
+	for k := i; k != j; k = (k + 1) % n {
+		arc = append(arc, pts[(k+1)%n])
+	}
+	return arc
+}
+
+// rdp implements Douglas-Peucker on the open polyline arc, keeping
+// both endpoints. Any interior vertex whose perpendicular distance to
+// the chord between arc's endpoints is >= epsilon forces a split and
+// recursion on both halves; otherwise the whole arc collapses to its
+// chord.
+func rdp(arc []Point, epsilon float64) []Point {
+	if len(arc) < 3 {
+		return arc
+	}
+	a, b := arc[0], arc[len(arc)-1]
+	far, at := -1.0, -1
+	for i := 1; i < len(arc)-1; i++ {
+		if d := perpDist(a, b, arc[i]); d > far {
+			far, at = d, i
+		}
+	}
+	if far < epsilon {
+		return []Point{a, b}
+	}
+	left := rdp(arc[:at+1], epsilon)
+	right := rdp(arc[at:], epsilon)
+	return append(left[:len(left)-1], right...)
+}
+
+// Simplify reduces s to a subset of its own points via the
+// Douglas-Peucker algorithm: the two points farthest apart split the
+// ring into two chords, and each chord is recursively replaced by
+// straight runs wherever every point it skips lies within epsilon of
+// the chord. Unlike dissolve, which only drops exactly collinear
+// points, Simplify also drops near-collinear ones, trading some
+// boundary accuracy (bounded by epsilon) for fewer points.
+//
+// The result is run back through Rationalize, which also recomputes
+// Hole from the (unchanged) winding order, and is checked for
+// self-intersection with a single SelfIntersections sweep; an error is
+// returned if simplification introduced a self-crossing, in which
+// case the caller should retry with a smaller epsilon.
+func (s *Shape) Simplify(epsilon float64) (*Shape, error) {
+	if s == nil {
+		return nil, nil
+	}
+	pts := s.PS
+	if len(pts) < 3 {
+		return nil, fmt.Errorf("shape %q requires 3 or more points: got=%d", s.Index, len(pts))
+	}
+	if len(pts) == 3 {
+		return s.Duplicate(), nil
+	}
+	i, j := farthestPair(pts)
+	r1 := rdp(arcBetween(pts, i, j), epsilon)
+	r2 := rdp(arcBetween(pts, j, i), epsilon)
+	out := append(append([]Point{}, r1[:len(r1)-1]...), r2...)
+	if len(out) > 1 && MatchPoint(out[0], out[len(out)-1]) {
+		out = out[:len(out)-1]
+	}
+	poly, err := Rationalize(out)
+	if err != nil {
+		return nil, err
+	}
+	poly.Index = s.Index
+
+	if len(poly.SelfIntersections()) != 0 {
+		return nil, fmt.Errorf("simplifying shape %q with epsilon=%v produced a self-crossing: retry with a smaller epsilon", s.Index, epsilon)
+	}
+	return poly, nil
+}
+
+// Simplify applies Shape.Simplify to every shape in p, preserving
+// each shape's Index (and, via Rationalize, its Hole orientation).
+func (p *Shapes) Simplify(epsilon float64) (*Shapes, error) {
+	if p == nil {
+		return nil, nil
+	}
+	out := &Shapes{}
+	for _, s := range p.P {
+		poly, err := s.Simplify(epsilon)
+		if err != nil {
+			return nil, err
+		}
+		out.P = append(out.P, poly)
+	}
+	return out, nil
+}
@@ -0,0 +1,69 @@
+package polygon
+
+import "testing"
+
+func TestSimplifyDropsNearCollinearPoints(t *testing.T) {
+	s, err := Rationalize([]Point{
+		{0, 0}, {1, 0.001}, {2, 0}, {2, 2}, {0, 2},
+	})
+	if err != nil {
+		t.Fatalf("failed to build shape: %v", err)
+	}
+	got, err := s.Simplify(0.01)
+	if err != nil {
+		t.Fatalf("Simplify failed: %v", err)
+	}
+	checkPoints(t, "simplified", got.PS, square(Point{0, 0}, Point{2, 2}))
+}
+
+func TestSimplifyKeepsSharpCorners(t *testing.T) {
+	s, err := Rationalize([]Point{
+		{0, 0}, {1, 1}, {2, 0}, {2, 2}, {0, 2},
+	})
+	if err != nil {
+		t.Fatalf("failed to build shape: %v", err)
+	}
+	got, err := s.Simplify(0.01)
+	if err != nil {
+		t.Fatalf("Simplify failed: %v", err)
+	}
+	if len(got.PS) != 5 {
+		t.Errorf("expected the sharp notch to survive, got %d points: %v", len(got.PS), got.PS)
+	}
+}
+
+func TestSimplifyPreservesIndexAndHole(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	ss = ss.Builder(Point{4, 4}, Point{4.001, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	if !ss.P[1].Hole {
+		t.Fatalf("expected second shape to be a hole")
+	}
+	got, err := ss.Simplify(0.01)
+	if err != nil {
+		t.Fatalf("Simplify failed: %v", err)
+	}
+	if got.P[0].Index != ss.P[0].Index || got.P[1].Index != ss.P[1].Index {
+		t.Errorf("Simplify should preserve Index, got %q, %q", got.P[0].Index, got.P[1].Index)
+	}
+	if !got.P[1].Hole {
+		t.Errorf("Simplify should preserve the hole orientation")
+	}
+}
+
+func TestSimplifyRejectsSelfCrossing(t *testing.T) {
+	// A thin strip whose bottom edge rises in a spike (1.1 high) that
+	// sits inside a shallow bump (0.2 high) on the top edge. At
+	// epsilon=0.5 the bump is dropped but the spike is kept, so the
+	// spike pokes through the straightened top edge.
+	s, err := Rationalize([]Point{
+		{0, 0}, {4, 0}, {5, 1.1}, {6, 0}, {10, 0},
+		{10, 1}, {6, 1}, {5, 1.2}, {4, 1}, {0, 1},
+	})
+	if err != nil {
+		t.Fatalf("failed to build shape: %v", err)
+	}
+	if _, err := s.Simplify(0.5); err == nil {
+		t.Fatalf("expected an error from a self-crossing simplification")
+	}
+}
@@ -0,0 +1,287 @@
+package polygon
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// segIdx is one ring edge as seen by the sweep: a and b are its
+// endpoints ordered left to right (then bottom to top), and i is the
+// caller's own number for the edge, reported back as crossHit.I/J.
+type segIdx struct {
+	a, b Point
+	i    int
+}
+
+// sweepEventKind distinguishes the three events a Bentley–Ottmann
+// sweep reacts to.
+type sweepEventKind int
+
+const (
+	evLeft sweepEventKind = iota
+	evCross
+	evRight
+)
+
+// sweepEvent is one left-endpoint, right-endpoint, or crossing event,
+// ordered by (X, Y, kind) so events sharing a point are processed
+// left-before-cross-before-right: that way the status structure always
+// holds every segment touching a point before its neighbours there are
+// tested.
+type sweepEvent struct {
+	at     Point
+	kind   sweepEventKind
+	s1, s2 int // index into segs; s2 is only meaningful for evCross
+}
+
+type eventQueue []sweepEvent
+
+func (q eventQueue) Len() int { return len(q) }
+func (q eventQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if a.at.X != b.at.X {
+		return a.at.X < b.at.X
+	}
+	if a.at.Y != b.at.Y {
+		return a.at.Y < b.at.Y
+	}
+	return a.kind < b.kind
+}
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *eventQueue) Push(x any)   { *q = append(*q, x.(sweepEvent)) }
+func (q *eventQueue) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
+
+// yAt returns seg's Y value at sweep position x. A near-vertical
+// segment (dx below Zeroish) has no single Y at x, so it reports its
+// lower endpoint's Y; every caller only ever asks for x within (or at
+// the edge of) seg's own span, so this just needs to be consistent,
+// not exact.
+func yAt(seg segIdx, x float64) float64 {
+	dx := seg.b.X - seg.a.X
+	if math.Abs(dx) < Zeroish {
+		return seg.a.Y
+	}
+	return seg.a.Y + (x-seg.a.X)/dx*(seg.b.Y-seg.a.Y)
+}
+
+// sharesEndpoint reports whether segs i and j are consecutive ring
+// edges that legitimately meet at a shared vertex - not a crossing.
+func sharesEndpoint(segs []segIdx, i, j int) bool {
+	a, b := segs[i], segs[j]
+	return MatchPoint(a.a, b.a) || MatchPoint(a.a, b.b) || MatchPoint(a.b, b.a) || MatchPoint(a.b, b.b)
+}
+
+// crossHit is one point where segs[I] and segs[J] cross, as found by
+// sweepSegmentPairs.
+type crossHit struct {
+	I, J int
+	At   Point
+}
+
+// sweepSegmentPairs runs a Bentley–Ottmann plane sweep over segs (each
+// already oriented left to right) and returns one crossHit for every
+// pair of segments that cross, other than pairs skip reports true for
+// (typically two ring edges that legitimately meet at a shared
+// vertex, not a crossing).
+//
+// The segments active at the current sweep X are held in a single
+// slice kept sorted by Y at that X - the "plain sorted slice" status
+// structure the request allows as a first cut, rather than a balanced
+// BST. A left-endpoint event inserts a segment and tests it against
+// its new neighbours; a right-endpoint event removes one and tests
+// the neighbours it leaves adjacent; a crossing event swaps the two
+// segments' order in the slice and tests the pairs newly adjacent on
+// either side, pushing any further crossings it finds as future
+// events. Vertical segments are handled by yAt's fallback to an
+// endpoint Y, and intersect() itself already tolerates the coincident
+// and collinear cases (MatchPoint/hold) the request calls out.
+func sweepSegmentPairs(segs []segIdx, skip func(i, j int) bool) []crossHit {
+	var events eventQueue
+	for i, s := range segs {
+		events = append(events, sweepEvent{at: s.a, kind: evLeft, s1: i})
+		events = append(events, sweepEvent{at: s.b, kind: evRight, s1: i})
+	}
+	heap.Init(&events)
+
+	var status []int
+	indexOf := func(i int) int {
+		for p, si := range status {
+			if si == i {
+				return p
+			}
+		}
+		return -1
+	}
+
+	isVertical := func(i int) bool { return segs[i].a.X == segs[i].b.X }
+	var activeVerticals []int // segs-index of every vertical segment currently in status
+
+	var found []crossHit
+	tested := make(map[[2]int]bool)
+	testPair := func(i, j int, sweepX float64) {
+		if i == j || skip(i, j) {
+			return
+		}
+		key := [2]int{i, j}
+		if i > j {
+			key = [2]int{j, i}
+		}
+		if tested[key] {
+			return
+		}
+		tested[key] = true
+		hit, _, _, at := intersect(segs[i].a, segs[i].b, segs[j].a, segs[j].b)
+		if !hit {
+			return
+		}
+		found = append(found, crossHit{I: segs[i].i, J: segs[j].i, At: at})
+		if at.X > sweepX+Zeroish {
+			heap.Push(&events, sweepEvent{at: at, kind: evCross, s1: i, s2: j})
+		}
+	}
+
+	for events.Len() > 0 {
+		ev := heap.Pop(&events).(sweepEvent)
+		switch ev.kind {
+		case evLeft:
+			i := ev.s1
+			p := 0
+			for p < len(status) && yAt(segs[status[p]], ev.at.X) < yAt(segs[i], ev.at.X)-Zeroish {
+				p++
+			}
+			status = append(status, 0)
+			copy(status[p+1:], status[p:])
+			status[p] = i
+			if p > 0 {
+				testPair(status[p-1], i, ev.at.X)
+			}
+			if p+1 < len(status) {
+				testPair(i, status[p+1], ev.at.X)
+			}
+			// yAt falls back to a vertical segment's lower endpoint,
+			// which only approximates its true (undefined) Y at this
+			// X, so a vertical segment can land at a status position
+			// that isn't actually adjacent to everything it crosses.
+			// Compensate by testing it against every other active
+			// segment directly, and testing every newly active
+			// segment against every active vertical: both sides of
+			// that exchange are needed since either one can be the
+			// one just inserted.
+			if isVertical(i) {
+				for _, v := range status {
+					testPair(v, i, ev.at.X)
+				}
+				activeVerticals = append(activeVerticals, i)
+			} else {
+				for _, v := range activeVerticals {
+					testPair(v, i, ev.at.X)
+				}
+			}
+		case evRight:
+			p := indexOf(ev.s1)
+			if p < 0 {
+				continue
+			}
+			status = append(status[:p], status[p+1:]...)
+			if p > 0 && p < len(status) {
+				testPair(status[p-1], status[p], ev.at.X)
+			}
+			if isVertical(ev.s1) {
+				for k, v := range activeVerticals {
+					if v == ev.s1 {
+						activeVerticals = append(activeVerticals[:k], activeVerticals[k+1:]...)
+						break
+					}
+				}
+			}
+		case evCross:
+			pi, pj := indexOf(ev.s1), indexOf(ev.s2)
+			if pi < 0 || pj < 0 || pi == pj {
+				continue
+			}
+			if pi > pj {
+				pi, pj = pj, pi
+			}
+			if pj != pi+1 {
+				// Stale event: something else already reordered
+				// these two since it was queued.
+				continue
+			}
+			status[pi], status[pj] = status[pj], status[pi]
+			if pi > 0 {
+				testPair(status[pi-1], status[pi], ev.at.X)
+			}
+			if pj+1 < len(status) {
+				testPair(status[pj], status[pj+1], ev.at.X)
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		a, b := found[i].At, found[j].At
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		if found[i].I != found[j].I {
+			return found[i].I < found[j].I
+		}
+		return found[i].J < found[j].J
+	})
+	return found
+}
+
+// sweepCrossings runs sweepSegmentPairs over segs and returns every
+// distinct point, other than a shared endpoint between adjacent
+// segments, where two segments cross.
+func sweepCrossings(segs []segIdx) []Point {
+	hits := sweepSegmentPairs(segs, func(i, j int) bool { return sharesEndpoint(segs, i, j) })
+	found := make(map[Point]bool, len(hits))
+	for _, h := range hits {
+		found[h.At] = true
+	}
+	out := make([]Point, 0, len(found))
+	for pt := range found {
+		out = append(out, pt)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].X != out[j].X {
+			return out[i].X < out[j].X
+		}
+		return out[i].Y < out[j].Y
+	})
+	return out
+}
+
+// SelfIntersections reports every point, other than the vertex shared
+// by two consecutive edges, where s's own perimeter crosses itself -
+// for example the centre of a bowtie ring. It is computed with
+// sweepSegmentPairs, the same Bentley–Ottmann plane sweep crossings()
+// now uses to find where two distinct shapes cross (see crossings() in
+// polygon.go), so it stays cheap even for the hundreds of vertices a
+// tessellated arc can produce; an empty result means s is a simple
+// polygon safe to pass to Rationalize, Union, and the rest of this
+// package's tracer, which all assume one.
+func (s *Shape) SelfIntersections() []Point {
+	if s == nil || len(s.PS) < 3 {
+		return nil
+	}
+	segs := make([]segIdx, len(s.PS))
+	for i := range s.PS {
+		a, b := s.PS[i], s.PS[(i+1)%len(s.PS)]
+		if a.X > b.X || (a.X == b.X && a.Y > b.Y) {
+			a, b = b, a
+		}
+		segs[i] = segIdx{a: a, b: b, i: i}
+	}
+	return sweepCrossings(segs)
+}
@@ -0,0 +1,95 @@
+package polygon
+
+import "testing"
+
+func TestSweepCrossingsFindsSimpleX(t *testing.T) {
+	segs := []segIdx{
+		{a: Point{0, 0}, b: Point{2, 2}, i: 0},
+		{a: Point{0, 2}, b: Point{2, 0}, i: 1},
+	}
+	got := sweepCrossings(segs)
+	if len(got) != 1 {
+		t.Fatalf("got %d crossings, want 1: %v", len(got), got)
+	}
+	if got[0] != (Point{1, 1}) {
+		t.Errorf("crossing = %v, want {1 1}", got[0])
+	}
+}
+
+func TestSweepCrossingsHandlesVerticalEdge(t *testing.T) {
+	segs := []segIdx{
+		{a: Point{1, 0}, b: Point{1, 2}, i: 0}, // vertical
+		{a: Point{0, 1}, b: Point{2, 1}, i: 1}, // horizontal
+	}
+	got := sweepCrossings(segs)
+	if len(got) != 1 || got[0] != (Point{1, 1}) {
+		t.Fatalf("got %v, want a single crossing at {1 1}", got)
+	}
+}
+
+func TestSweepCrossingsIgnoresAdjacentEdges(t *testing.T) {
+	// A right triangle's three edges: consecutive edges share a
+	// vertex and must not be reported as crossings.
+	segs := []segIdx{
+		{a: Point{0, 0}, b: Point{2, 0}, i: 0},
+		{a: Point{2, 0}, b: Point{0, 2}, i: 1},
+		{a: Point{0, 0}, b: Point{0, 2}, i: 2},
+	}
+	got := sweepCrossings(segs)
+	if len(got) != 0 {
+		t.Errorf("got %v, want no crossings for a simple triangle", got)
+	}
+}
+
+func TestSweepSegmentPairsFindsBothCrossingsOfOverlappingSquares(t *testing.T) {
+	// Two axis-aligned, overlapping squares cross at two points; the
+	// far one, {1,2}, is only found if a vertical edge already active
+	// in the status is tested against every other active segment, not
+	// just its immediate neighbours there (yAt's fallback for a
+	// vertical segment approximates its position with its lower
+	// endpoint, which isn't always enough to make it adjacent to
+	// everything it actually crosses).
+	segs := []segIdx{
+		{a: Point{0, 0}, b: Point{2, 0}, i: 0},
+		{a: Point{2, 0}, b: Point{2, 2}, i: 1},
+		{a: Point{0, 2}, b: Point{2, 2}, i: 2},
+		{a: Point{0, 0}, b: Point{0, 2}, i: 3},
+		{a: Point{1, 1}, b: Point{3, 1}, i: 4},
+		{a: Point{3, 1}, b: Point{3, 3}, i: 5},
+		{a: Point{1, 3}, b: Point{3, 3}, i: 6},
+		{a: Point{1, 1}, b: Point{1, 3}, i: 7},
+	}
+	hits := sweepSegmentPairs(segs, func(i, j int) bool { return i < 4 == (j < 4) })
+	found := make(map[Point]bool)
+	for _, h := range hits {
+		found[h.At] = true
+	}
+	for _, want := range []Point{{2, 1}, {1, 2}} {
+		if !found[want] {
+			t.Errorf("missing crossing %v, got %v", want, hits)
+		}
+	}
+}
+
+func TestSelfIntersectionsSimplePolygon(t *testing.T) {
+	var s *Shape
+	ss := (&Shapes{}).Builder(square(Point{0, 0}, Point{4, 4})...)
+	s = ss.P[0]
+	if got := s.SelfIntersections(); len(got) != 0 {
+		t.Errorf("SelfIntersections = %v, want none for a simple square", got)
+	}
+}
+
+func TestSelfIntersectionsBowtie(t *testing.T) {
+	// A figure-eight: (0,0)->(4,4)->(4,0)->(0,4)->(0,0), crossing
+	// itself at its centre, (2,2).
+	ss := (&Shapes{}).Builder(Point{0, 0}, Point{4, 4}, Point{4, 0}, Point{0, 4})
+	s := ss.P[0]
+	got := s.SelfIntersections()
+	if len(got) != 1 {
+		t.Fatalf("SelfIntersections = %v, want exactly one crossing", got)
+	}
+	if got[0] != (Point{2, 2}) {
+		t.Errorf("crossing = %v, want {2 2}", got[0])
+	}
+}
@@ -0,0 +1,640 @@
+package polygon
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Triangle is a single CCW-wound triangle, as emitted by Triangulate.
+type Triangle struct {
+	A, B, C Point
+}
+
+// earVert is a node in the circular doubly-linked list Triangulate
+// walks while clipping ears. prev/next are the polygon's own
+// adjacency, mutated as holes are bridged in and ears are clipped
+// off; prevZ/nextZ thread the same nodes in Z-order-curve order, so
+// isEarHashed's "is any other vertex inside this ear" test can scan
+// just the handful of nodes near the ear instead of the whole
+// remaining ring.
+type earVert struct {
+	pt           Point
+	steiner      bool
+	prev, next   *earVert
+	prevZ, nextZ *earVert
+	z            int64
+}
+
+// earArea returns twice the signed area of the path p->q->r. Given
+// how Triangulate fixes up ring winding (outer forced one way, holes
+// the other - see linkedRing), a value >= 0 here means the path turns
+// the "reflex" way for whichever ring it came from.
+func earArea(p, q, r Point) float64 {
+	return (q.Y-p.Y)*(r.X-q.X) - (q.X-p.X)*(r.Y-q.Y)
+}
+
+// earPointInTriangle reports whether v lies inside (or on the
+// boundary of) the triangle a, b, c.
+func earPointInTriangle(a, b, c, v Point) bool {
+	return (c.X-v.X)*(a.Y-v.Y)-(a.X-v.X)*(c.Y-v.Y) >= 0 &&
+		(a.X-v.X)*(b.Y-v.Y)-(b.X-v.X)*(a.Y-v.Y) >= 0 &&
+		(b.X-v.X)*(c.Y-v.Y)-(c.X-v.X)*(b.Y-v.Y) >= 0
+}
+
+// insertVert inserts a new node for pt into the circular list right
+// after last (or starts a fresh single-node list if last is nil).
+func insertVert(pt Point, last *earVert) *earVert {
+	p := &earVert{pt: pt}
+	if last == nil {
+		p.prev, p.next = p, p
+	} else {
+		p.next = last.next
+		p.prev = last
+		last.next.prev = p
+		last.next = p
+	}
+	return p
+}
+
+// removeVert unlinks p from both the prev/next and prevZ/nextZ
+// chains, and returns p.prev so callers can keep walking the ring.
+func removeVert(p *earVert) *earVert {
+	p.next.prev = p.prev
+	p.prev.next = p.next
+	if p.prevZ != nil {
+		p.prevZ.nextZ = p.nextZ
+	}
+	if p.nextZ != nil {
+		p.nextZ.prevZ = p.prevZ
+	}
+	return p.prev
+}
+
+// ringArea returns twice the signed area of the closed ring pts,
+// negative for a counterclockwise ring and positive for a clockwise
+// one.
+func ringArea(pts []Point) float64 {
+	var sum float64
+	n := len(pts)
+	for i := 0; i < n; i++ {
+		a, b := pts[i], pts[(i+1)%n]
+		sum += (b.X - a.X) * (b.Y + a.Y)
+	}
+	return sum
+}
+
+// linkedRing builds a circular doubly-linked list from pts, reversing
+// the order if needed so the list winds counterclockwise when ccw is
+// true, clockwise otherwise.
+func linkedRing(pts []Point, ccw bool) *earVert {
+	var last *earVert
+	if ccw == (ringArea(pts) < 0) {
+		for _, p := range pts {
+			last = insertVert(p, last)
+		}
+	} else {
+		for i := len(pts) - 1; i >= 0; i-- {
+			last = insertVert(pts[i], last)
+		}
+	}
+	if last != nil && MatchPoint(last.pt, last.next.pt) {
+		next := last.next
+		removeVert(last)
+		last = next
+	}
+	return last
+}
+
+// filterPoints removes p.next whenever it exactly repeats p, or p
+// itself whenever it is exactly collinear with its neighbours, from
+// start up to (and including a final pass at) end. It returns a node
+// still on the surviving ring, since start or end may themselves have
+// been removed.
+func filterPoints(start, end *earVert) *earVert {
+	if start == nil {
+		return start
+	}
+	if end == nil {
+		end = start
+	}
+	p := start
+	for again := true; again || p != end; {
+		again = false
+		if !p.steiner && (MatchPoint(p.pt, p.next.pt) || earArea(p.prev.pt, p.pt, p.next.pt) == 0) {
+			p = removeVert(p)
+			end = p
+			if p == p.next {
+				break
+			}
+			again = true
+		} else {
+			p = p.next
+		}
+	}
+	return end
+}
+
+// getLeftmost returns the node of the ring starting at start with the
+// smallest X (ties broken by smallest Y).
+func getLeftmost(start *earVert) *earVert {
+	leftmost := start
+	for p := start.next; p != start; p = p.next {
+		if p.pt.X < leftmost.pt.X || (p.pt.X == leftmost.pt.X && p.pt.Y < leftmost.pt.Y) {
+			leftmost = p
+		}
+	}
+	return leftmost
+}
+
+// locallyInside reports whether the segment a->b lies inside the
+// polygon immediately next to vertex a, given a's own local winding.
+func locallyInside(a, b *earVert) bool {
+	if earArea(a.prev.pt, a.pt, a.next.pt) < 0 {
+		return earArea(a.pt, b.pt, a.next.pt) >= 0 && earArea(a.pt, a.prev.pt, b.pt) >= 0
+	}
+	return earArea(a.pt, b.pt, a.prev.pt) < 0 || earArea(a.pt, a.next.pt, b.pt) < 0
+}
+
+// middleInside reports whether the midpoint of a->b lies inside the
+// ring a belongs to, via a simple ray-casting count.
+func middleInside(a, b *earVert) bool {
+	px, py := (a.pt.X+b.pt.X)/2, (a.pt.Y+b.pt.Y)/2
+	inside := false
+	p := a
+	for {
+		if (p.pt.Y > py) != (p.next.pt.Y > py) && p.next.pt.Y != p.pt.Y &&
+			px < (p.next.pt.X-p.pt.X)*(py-p.pt.Y)/(p.next.pt.Y-p.pt.Y)+p.pt.X {
+			inside = !inside
+		}
+		p = p.next
+		if p == a {
+			break
+		}
+	}
+	return inside
+}
+
+// splitPolygon cuts the ring between a and b, duplicating both nodes
+// so that a,b and their duplicates bound two now-separate rings, a
+// bridge connecting an outer ring to a hole (or splitting a sliver
+// off a stuck ring) without needing to touch anything else.
+func splitPolygon(a, b *earVert) *earVert {
+	a2 := &earVert{pt: a.pt}
+	b2 := &earVert{pt: b.pt}
+	an, bp := a.next, b.prev
+
+	a.next, b.prev = b, a
+	a2.next, a2.prev = an, b2
+	an.prev = a2
+	b2.next, b2.prev = a2, bp
+	bp.next = b2
+
+	return b2
+}
+
+// findHoleBridge finds the outer-ring vertex that hole (some node of
+// a hole ring) can be safely bridged to: it ray-casts left from
+// hole's own position to find the nearest outer edge, takes that
+// edge's lesser-X endpoint as a candidate, and then - if any other
+// outer vertex sits inside the candidate triangle - refines the
+// candidate to whichever of those is closest by angle, so the bridge
+// never crosses the outer ring.
+func findHoleBridge(hole, outer *earVert) *earVert {
+	hx, hy := hole.pt.X, hole.pt.Y
+	qx := math.Inf(-1)
+	var m *earVert
+	p := outer
+	for {
+		if hy <= p.pt.Y && hy >= p.next.pt.Y && p.next.pt.Y != p.pt.Y {
+			x := p.pt.X + (hy-p.pt.Y)*(p.next.pt.X-p.pt.X)/(p.next.pt.Y-p.pt.Y)
+			if x <= hx && x > qx {
+				qx = x
+				if x == hx {
+					if hy == p.pt.Y {
+						return p
+					}
+					if hy == p.next.pt.Y {
+						return p.next
+					}
+				}
+				if p.pt.X < p.next.pt.X {
+					m = p
+				} else {
+					m = p.next
+				}
+			}
+		}
+		p = p.next
+		if p == outer {
+			break
+		}
+	}
+	if m == nil {
+		return nil
+	}
+	if hx == qx {
+		return m.prev
+	}
+
+	stop := m
+	mx, my := m.pt.X, m.pt.Y
+	tanMin := math.Inf(1)
+	for p := m.next; p != stop; p = p.next {
+		var left, right float64
+		if hy < my {
+			left, right = hx, qx
+		} else {
+			left, right = qx, hx
+		}
+		if hx >= p.pt.X && p.pt.X >= mx && hx != p.pt.X &&
+			earPointInTriangle(Point{left, hy}, Point{mx, my}, Point{right, hy}, p.pt) {
+			tan := math.Abs(hy-p.pt.Y) / (hx - p.pt.X)
+			if (tan < tanMin || (tan == tanMin && p.pt.X > mx)) && locallyInside(p, hole) {
+				m, tanMin = p, tan
+			}
+		}
+	}
+	return m
+}
+
+// eliminateHole bridges hole (the leftmost node of some hole ring)
+// into outer, returning a node guaranteed to still be on the merged
+// ring (outer itself may have been pruned away as a collinear point
+// once the bridge's duplicate vertices are spliced in).
+func eliminateHole(hole, outer *earVert) *earVert {
+	bridge := findHoleBridge(hole, outer)
+	if bridge == nil {
+		return outer
+	}
+	other := splitPolygon(bridge, hole)
+	filterPoints(other, other.next)
+	return filterPoints(bridge, bridge.next)
+}
+
+// sign returns -1, 0 or 1 according to the sign of v.
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// onSegment reports whether q, known to be collinear with p and r,
+// also lies within their bounding box (i.e. between them).
+func onSegment(p, q, r Point) bool {
+	return q.X <= math.Max(p.X, r.X) && q.X >= math.Min(p.X, r.X) &&
+		q.Y <= math.Max(p.Y, r.Y) && q.Y >= math.Min(p.Y, r.Y)
+}
+
+// segmentsCross reports whether open segments p1-q1 and p2-q2 cross
+// or touch.
+func segmentsCross(p1, q1, p2, q2 Point) bool {
+	o1 := sign(earArea(p1, q1, p2))
+	o2 := sign(earArea(p1, q1, q2))
+	o3 := sign(earArea(p2, q2, p1))
+	o4 := sign(earArea(p2, q2, q1))
+	if o1 != o2 && o3 != o4 {
+		return true
+	}
+	if o1 == 0 && onSegment(p1, p2, q1) {
+		return true
+	}
+	if o2 == 0 && onSegment(p1, q2, q1) {
+		return true
+	}
+	if o3 == 0 && onSegment(p2, p1, q2) {
+		return true
+	}
+	if o4 == 0 && onSegment(p2, q1, q2) {
+		return true
+	}
+	return false
+}
+
+// intersectsRing reports whether diagonal a->b crosses any edge of
+// the ring a belongs to, other than the two edges already touching a
+// or b.
+func intersectsRing(a, b *earVert) bool {
+	p := a
+	for {
+		if p != a && p.next != a && p != b && p.next != b && segmentsCross(p.pt, p.next.pt, a.pt, b.pt) {
+			return true
+		}
+		p = p.next
+		if p == a {
+			break
+		}
+	}
+	return false
+}
+
+// isValidDiagonal reports whether a->b is a diagonal splitEarcut can
+// safely cut the ring along: it must not already be an edge, must not
+// cross the ring, and the ring's own interior must genuinely lie
+// along it on both sides.
+func isValidDiagonal(a, b *earVert) bool {
+	if a.next == b || a.prev == b || intersectsRing(a, b) {
+		return false
+	}
+	return locallyInside(a, b) && locallyInside(b, a) && middleInside(a, b) &&
+		(earArea(a.prev.pt, a.pt, b.prev.pt) != 0 || earArea(a.pt, b.prev.pt, b.pt) != 0)
+}
+
+// zOrder computes the Z-order (Morton) code of (x, y), quantized
+// against the ring's bounding box by invSize, interleaving the low 32
+// bits of each quantized coordinate.
+func zOrder(x, y, minX, minY, invSize float64) int64 {
+	xi := int64((x - minX) * invSize)
+	yi := int64((y - minY) * invSize)
+
+	xi = (xi | (xi << 16)) & 0x0000FFFF0000FFFF
+	xi = (xi | (xi << 8)) & 0x00FF00FF00FF00FF
+	xi = (xi | (xi << 4)) & 0x0F0F0F0F0F0F0F0F
+	xi = (xi | (xi << 2)) & 0x3333333333333333
+	xi = (xi | (xi << 1)) & 0x5555555555555555
+
+	yi = (yi | (yi << 16)) & 0x0000FFFF0000FFFF
+	yi = (yi | (yi << 8)) & 0x00FF00FF00FF00FF
+	yi = (yi | (yi << 4)) & 0x0F0F0F0F0F0F0F0F
+	yi = (yi | (yi << 2)) & 0x3333333333333333
+	yi = (yi | (yi << 1)) & 0x5555555555555555
+
+	return xi | (yi << 1)
+}
+
+// indexCurve assigns every node of the ring starting at start its
+// Z-order code, and threads prevZ/nextZ to mirror prev/next, cutting
+// the chain so sortLinked can merge-sort it as a plain (non-circular)
+// singly-linked list.
+func indexCurve(start *earVert, minX, minY, invSize float64) {
+	p := start
+	for {
+		if p.z == 0 {
+			p.z = zOrder(p.pt.X, p.pt.Y, minX, minY, invSize)
+		}
+		p.prevZ, p.nextZ = p.prev, p.next
+		p = p.next
+		if p == start {
+			break
+		}
+	}
+	start.prevZ.nextZ = nil
+	start.prevZ = nil
+}
+
+// sortLinked merge-sorts the nextZ/prevZ-threaded list by z, returning
+// its new head.
+func sortLinked(list *earVert) *earVert {
+	inSize := 1
+	for {
+		p := list
+		list = nil
+		var tail *earVert
+		numMerges := 0
+		for p != nil {
+			numMerges++
+			q := p
+			pSize := 0
+			for i := 0; i < inSize; i++ {
+				pSize++
+				q = q.nextZ
+				if q == nil {
+					break
+				}
+			}
+			qSize := inSize
+			for pSize > 0 || (qSize > 0 && q != nil) {
+				var e *earVert
+				switch {
+				case pSize == 0:
+					e, q, qSize = q, q.nextZ, qSize-1
+				case qSize == 0 || q == nil:
+					e, p, pSize = p, p.nextZ, pSize-1
+				case p.z <= q.z:
+					e, p, pSize = p, p.nextZ, pSize-1
+				default:
+					e, q, qSize = q, q.nextZ, qSize-1
+				}
+				if tail != nil {
+					tail.nextZ = e
+				} else {
+					list = e
+				}
+				e.prevZ = tail
+				tail = e
+			}
+			p = q
+		}
+		tail.nextZ = nil
+		if numMerges <= 1 {
+			return list
+		}
+		inSize *= 2
+	}
+}
+
+// isEar reports whether clipping the triangle (ear.prev, ear, ear.next)
+// is valid: the triangle must turn the non-reflex way, and no other
+// vertex of the ring may fall inside it. This is the plain O(n) check,
+// used below isEarThreshold vertices where building a Z-order index
+// doesn't pay for itself.
+func isEar(ear *earVert) bool {
+	a, b, c := ear.prev.pt, ear.pt, ear.next.pt
+	if earArea(a, b, c) >= 0 {
+		return false
+	}
+	for p := ear.next.next; p != ear.prev; p = p.next {
+		if earPointInTriangle(a, b, c, p.pt) && earArea(p.prev.pt, p.pt, p.next.pt) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isEarThreshold is the size (the outer ring's vertex count plus one
+// per hole) at or above which Triangulate builds a
+// Z-order index over its vertices, so isEarHashed can restrict its
+// "any other vertex inside this ear" scan to a local neighbourhood
+// instead of the whole remaining ring.
+const isEarThreshold = 80
+
+// isEarHashed is isEar's equivalent restricted to the vertices whose
+// Z-order code falls within the ear's own bounding box, found by
+// walking outward from ear along the prevZ/nextZ chain until it
+// leaves that range.
+func isEarHashed(ear *earVert, minX, minY, invSize float64) bool {
+	a, b, c := ear.prev.pt, ear.pt, ear.next.pt
+	if earArea(a, b, c) >= 0 {
+		return false
+	}
+	minTX := math.Min(a.X, math.Min(b.X, c.X))
+	minTY := math.Min(a.Y, math.Min(b.Y, c.Y))
+	maxTX := math.Max(a.X, math.Max(b.X, c.X))
+	maxTY := math.Max(a.Y, math.Max(b.Y, c.Y))
+	minZ := zOrder(minTX, minTY, minX, minY, invSize)
+	maxZ := zOrder(maxTX, maxTY, minX, minY, invSize)
+
+	bad := func(p *earVert) bool {
+		return p != ear.prev && p != ear.next &&
+			earPointInTriangle(a, b, c, p.pt) && earArea(p.prev.pt, p.pt, p.next.pt) >= 0
+	}
+
+	p, n := ear.prevZ, ear.nextZ
+	for p != nil && p.z >= minZ && n != nil && n.z <= maxZ {
+		if bad(p) {
+			return false
+		}
+		p = p.prevZ
+		if bad(n) {
+			return false
+		}
+		n = n.nextZ
+	}
+	for p != nil && p.z >= minZ {
+		if bad(p) {
+			return false
+		}
+		p = p.prevZ
+	}
+	for n != nil && n.z <= maxZ {
+		if bad(n) {
+			return false
+		}
+		n = n.nextZ
+	}
+	return true
+}
+
+// earcutLinked repeatedly clips ears off the ring starting at ear,
+// appending each as a Triangle, until only a single triangle remains.
+// If a full pass finds no ear (a sliver earcut can't resolve by
+// ear-clipping alone), it falls back to cutting the ring at whatever
+// valid diagonal splitEarcut can find and recurses on the two halves.
+func earcutLinked(ear *earVert, tris []Triangle, minX, minY, invSize float64, hashed bool) []Triangle {
+	if ear == nil {
+		return tris
+	}
+	if hashed {
+		indexCurve(ear, minX, minY, invSize)
+		ear = sortLinked(ear)
+	}
+	stop := ear
+	for ear.prev != ear.next {
+		prev, next := ear.prev, ear.next
+		var good bool
+		if hashed {
+			good = isEarHashed(ear, minX, minY, invSize)
+		} else {
+			good = isEar(ear)
+		}
+		if good {
+			tris = append(tris, Triangle{prev.pt, ear.pt, next.pt})
+			removeVert(ear)
+			ear, stop = next.next, next.next
+			continue
+		}
+		ear = next
+		if ear == stop {
+			return splitEarcut(ear, tris, minX, minY, invSize, hashed)
+		}
+	}
+	return tris
+}
+
+// splitEarcut looks for any valid diagonal across the stuck ring
+// starting at start, cuts the ring there, and triangulates the two
+// resulting rings independently. It is earcutLinked's fallback for
+// the slivers plain ear-clipping can't make progress on.
+func splitEarcut(start *earVert, tris []Triangle, minX, minY, invSize float64, hashed bool) []Triangle {
+	a := start
+	for {
+		for b := a.next.next; b != a.prev; b = b.next {
+			if isValidDiagonal(a, b) {
+				c := splitPolygon(a, b)
+				a = filterPoints(a, a.next)
+				c = filterPoints(c, c.next)
+				tris = earcutLinked(a, tris, minX, minY, invSize, hashed)
+				return earcutLinked(c, tris, minX, minY, invSize, hashed)
+			}
+		}
+		a = a.next
+		if a == start {
+			return tris
+		}
+	}
+}
+
+// Triangulate converts shape i, treated as an outer ring with holeI
+// naming any of p's other shapes that are holes cut out of it, into a
+// mesh of non-overlapping triangles covering exactly that area - the
+// counterpart to Slice/VSlice for consumers (GL rendering, FEM-style
+// processing) that want a triangle mesh instead of scanlines.
+//
+// It follows the classic earcut approach: the outer ring and each
+// hole are linked into circular lists (forced counterclockwise and
+// clockwise respectively), every hole is bridged into the outer ring
+// by finding a mutually visible vertex (findHoleBridge), and then ears
+// - vertices whose own triangle is convex and contains no other
+// vertex - are repeatedly clipped off and emitted until only one
+// triangle remains. When the outer ring's vertex count plus the number
+// of holes reaches isEarThreshold, the ring is additionally indexed by a Z-order curve over their bounding box
+// (indexCurve/isEarHashed), restricting that "contains no other
+// vertex" test to a local neighbourhood rather than a full scan.
+func (p *Shapes) Triangulate(i int, holeI ...int) ([]Triangle, error) {
+	if p == nil || i < 0 || i >= len(p.P) {
+		return nil, fmt.Errorf("invalid index %d for shapes", i)
+	}
+	s := p.P[i]
+	if s.Hole {
+		return nil, fmt.Errorf("shape %d is a hole, not an outer ring", i)
+	}
+	if len(s.PS) < 3 {
+		return nil, fmt.Errorf("shape %d has fewer than 3 points", i)
+	}
+
+	outer := linkedRing(s.PS, true)
+	minX, minY, maxX, maxY := s.MinX, s.MinY, s.MaxX, s.MaxY
+
+	var holes []*earVert
+	for _, hi := range holeI {
+		if hi < 0 || hi >= len(p.P) {
+			return nil, fmt.Errorf("invalid hole index %d for shapes", hi)
+		}
+		h := p.P[hi]
+		if len(h.PS) < 3 {
+			return nil, fmt.Errorf("hole %d has fewer than 3 points", hi)
+		}
+		ring := linkedRing(h.PS, false)
+		if ring == ring.next {
+			ring.steiner = true
+		}
+		holes = append(holes, getLeftmost(ring))
+		minX, minY = min(minX, h.MinX), min(minY, h.MinY)
+		maxX, maxY = max(maxX, h.MaxX), max(maxY, h.MaxY)
+	}
+	sort.Slice(holes, func(i, j int) bool {
+		a, b := holes[i].pt, holes[j].pt
+		return a.X < b.X || (a.X == b.X && a.Y < b.Y)
+	})
+	for _, hole := range holes {
+		outer = eliminateHole(hole, outer)
+	}
+
+	size := math.Max(maxX-minX, maxY-minY)
+	invSize := 0.0
+	if size > 0 {
+		invSize = 32767.0 / size
+	}
+
+	var tris []Triangle
+	outer = filterPoints(outer, nil)
+	if outer == nil || outer.prev == outer || outer.prev.prev == outer {
+		return tris, nil
+	}
+	return earcutLinked(outer, tris, minX, minY, invSize, len(s.PS)+len(holes) >= isEarThreshold), nil
+}
@@ -0,0 +1,116 @@
+package polygon
+
+import (
+	"math"
+	"testing"
+)
+
+// triArea returns twice the signed (CCW-positive) area of t.
+func triArea(t Triangle) float64 {
+	return (t.B.X-t.A.X)*(t.C.Y-t.A.Y) - (t.B.Y-t.A.Y)*(t.C.X-t.A.X)
+}
+
+// sumArea totals the (unsigned) area covered by tris.
+func sumArea(tris []Triangle) float64 {
+	var sum float64
+	for _, t := range tris {
+		sum += math.Abs(triArea(t)) / 2
+	}
+	return sum
+}
+
+func TestTriangulateSquare(t *testing.T) {
+	var p *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{2, 2})...)
+	tris, err := p.Triangulate(0)
+	if err != nil {
+		t.Fatalf("Triangulate failed: %v", err)
+	}
+	if len(tris) != 2 {
+		t.Fatalf("expected 2 triangles, got %d: %v", len(tris), tris)
+	}
+	if got, want := sumArea(tris), 4.0; math.Abs(got-want) > Zeroish {
+		t.Errorf("total area = %v, want %v", got, want)
+	}
+	for _, tr := range tris {
+		if triArea(tr) <= 0 {
+			t.Errorf("triangle %v is not wound CCW", tr)
+		}
+	}
+}
+
+func TestTriangulateNonConvex(t *testing.T) {
+	var p *Shapes
+	p = p.Builder(Point{0, 0}, Point{4, 0}, Point{4, 4}, Point{2, 1}, Point{0, 4})
+	tris, err := p.Triangulate(0)
+	if err != nil {
+		t.Fatalf("Triangulate failed: %v", err)
+	}
+	if len(tris) != 3 {
+		t.Fatalf("expected 3 triangles, got %d: %v", len(tris), tris)
+	}
+	for _, tr := range tris {
+		if triArea(tr) <= 0 {
+			t.Errorf("triangle %v is not wound CCW", tr)
+		}
+	}
+}
+
+func TestTriangulateWithHole(t *testing.T) {
+	var p *Shapes
+	p = p.Builder(square(Point{0, 0}, Point{10, 10})...)
+	p = p.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	if !p.P[1].Hole {
+		t.Fatalf("expected second shape to be a hole")
+	}
+	tris, err := p.Triangulate(0, 1)
+	if err != nil {
+		t.Fatalf("Triangulate failed: %v", err)
+	}
+	if got, want := sumArea(tris), 100.0-4.0; math.Abs(got-want) > Zeroish {
+		t.Errorf("total area = %v, want %v (outer minus hole)", got, want)
+	}
+	for _, tr := range tris {
+		if triArea(tr) <= 0 {
+			t.Errorf("triangle %v is not wound CCW", tr)
+		}
+	}
+}
+
+func TestTriangulateRejectsHoleIndex(t *testing.T) {
+	var p *Shapes
+	p = p.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	if !p.P[0].Hole {
+		t.Fatalf("expected shape to be a hole")
+	}
+	if _, err := p.Triangulate(0); err == nil {
+		t.Fatalf("expected an error triangulating a hole as the outer ring")
+	}
+}
+
+func TestTriangulateManySidedPolygon(t *testing.T) {
+	const n = 200
+	pts := make([]Point, n)
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		pts[i] = Point{100 * math.Cos(a), 100 * math.Sin(a)}
+	}
+	var p *Shapes
+	p = p.Builder(pts...)
+	tris, err := p.Triangulate(0)
+	if err != nil {
+		t.Fatalf("Triangulate failed: %v", err)
+	}
+	if len(tris) != n-2 {
+		t.Fatalf("expected %d triangles, got %d", n-2, len(tris))
+	}
+	want := math.Pi * 100 * 100
+	if got := sumArea(tris); math.Abs(got-want)/want > 0.01 {
+		t.Errorf("total area = %v, want ~%v", got, want)
+	}
+	for _, tr := range tris {
+		if triArea(tr) <= 0 {
+			t.Errorf("triangle %v is not wound CCW", tr)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package polygon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// polyGroup is one WKT/GeoJSON polygon: an outer ring plus whichever
+// of Shapes.P's holes fall inside it.
+type polyGroup struct {
+	outer *Shape
+	holes []*Shape
+}
+
+// bbArea is a bounding-box proxy for a shape's area, used only to pick
+// the innermost of several candidate outer rings for a hole - the same
+// approach (*Shapes).Contains uses to disambiguate nested holes.
+func bbArea(s *Shape) float64 {
+	return (s.MaxX - s.MinX) * (s.MaxY - s.MinY)
+}
+
+// groupRings partitions p.P into the polygons WKT/GeoJSON expect: one
+// group per non-hole shape, with each hole assigned to the smallest
+// (innermost) non-hole shape whose perimeter contains one of its
+// points. A hole that lands inside no outer ring is dropped, since
+// neither output format has anywhere to put it.
+func groupRings(p *Shapes) []polyGroup {
+	var groups []polyGroup
+	for _, s := range p.P {
+		if !s.Hole {
+			groups = append(groups, polyGroup{outer: s})
+		}
+	}
+	for _, h := range p.P {
+		if !h.Hole || len(h.PS) == 0 {
+			continue
+		}
+		pt := h.PS[0]
+		best := -1
+		for gi, g := range groups {
+			if pt.X < g.outer.MinX || pt.X > g.outer.MaxX || pt.Y < g.outer.MinY || pt.Y > g.outer.MaxY {
+				continue
+			}
+			if !pt.prunedInside(g.outer, nil) {
+				continue
+			}
+			if best == -1 || bbArea(groups[gi].outer) < bbArea(groups[best].outer) {
+				best = gi
+			}
+		}
+		if best != -1 {
+			groups[best].holes = append(groups[best].holes, h)
+		}
+	}
+	return groups
+}
+
+// formatRing renders pts as a WKT ring: a parenthesized, comma
+// separated "X Y" coordinate list, closed by repeating the first
+// point as required by the WKT grammar (PS itself doesn't repeat it).
+func formatRing(pts []Point) string {
+	coords := make([]string, len(pts)+1)
+	for i, pt := range pts {
+		coords[i] = fmt.Sprintf("%g %g", pt.X, pt.Y)
+	}
+	coords[len(pts)] = coords[0]
+	return "(" + strings.Join(coords, ", ") + ")"
+}
+
+// MarshalWKT renders p as Well-Known Text: a single non-hole Shape
+// with its associated holes becomes a POLYGON, multiple top-level
+// shapes become a MULTIPOLYGON. Holes are matched to their enclosing
+// shape geometrically (see groupRings); a Shapes with no non-hole
+// members is an error, since WKT has no representation for a lone
+// hole.
+func MarshalWKT(p *Shapes) (string, error) {
+	if p == nil || len(p.P) == 0 {
+		return "", fmt.Errorf("MarshalWKT requires at least one shape")
+	}
+	groups := groupRings(p)
+	if len(groups) == 0 {
+		return "", fmt.Errorf("MarshalWKT: %d shapes, none of them a non-hole outer ring", len(p.P))
+	}
+	polys := make([]string, len(groups))
+	for i, g := range groups {
+		rings := make([]string, 1+len(g.holes))
+		rings[0] = formatRing(g.outer.PS)
+		for j, h := range g.holes {
+			rings[1+j] = formatRing(h.PS)
+		}
+		polys[i] = "(" + strings.Join(rings, ", ") + ")"
+	}
+	if len(groups) == 1 {
+		return "POLYGON " + polys[0], nil
+	}
+	return "MULTIPOLYGON (" + strings.Join(polys, ", ") + ")", nil
+}
+
+// splitGroups splits s into the contents of each of its top-level
+// parenthesized, comma-separated groups, stripping each group's own
+// enclosing parens. A comma nested inside a group is not a split
+// point, which is what lets this same helper peel one level of
+// POLYGON's or MULTIPOLYGON's nested ring structure at a time.
+func splitGroups(s string) []string {
+	var groups []string
+	depth := 0
+	start := -1
+	for i, c := range s {
+		switch c {
+		case '(':
+			if depth == 0 {
+				start = i + 1
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				groups = append(groups, s[start:i])
+			}
+		}
+	}
+	return groups
+}
+
+// parseCoordList parses a comma-separated "X Y" list - the contents of
+// one WKT ring - dropping a duplicated closing point if the ring
+// repeats its first point to close itself.
+func parseCoordList(s string) ([]Point, error) {
+	var pts []Point
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed WKT coordinate %q", strings.TrimSpace(part))
+		}
+		x, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed WKT coordinate %q: %w", part, err)
+		}
+		y, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed WKT coordinate %q: %w", part, err)
+		}
+		pts = append(pts, Point{X: x, Y: y})
+	}
+	if n := len(pts); n > 1 && pts[0] == pts[n-1] {
+		pts = pts[:n-1]
+	}
+	return pts, nil
+}
+
+// parseRingList parses body - the nested-paren ring list of a single
+// POLYGON, e.g. "((x y, x y, x y), (x y, x y, x y))" - into Shapes,
+// Rationalize giving each ring its Hole flag from its own winding per
+// this package's usual CCW/CW convention.
+func parseRingList(body string) ([]*Shape, error) {
+	wrapped := splitGroups(body)
+	if len(wrapped) != 1 {
+		return nil, fmt.Errorf("malformed WKT polygon: %q", body)
+	}
+	var shapes []*Shape
+	for _, ring := range splitGroups(wrapped[0]) {
+		pts, err := parseCoordList(ring)
+		if err != nil {
+			return nil, err
+		}
+		poly, err := Rationalize(pts)
+		if err != nil {
+			return nil, err
+		}
+		shapes = append(shapes, poly)
+	}
+	return shapes, nil
+}
+
+// ParseWKT parses a POLYGON or MULTIPOLYGON WKT string into Shapes,
+// one Shape per ring (outer and hole alike - Shapes itself has no
+// notion of which holes belong to which outer ring beyond their Hole
+// flag and geometry, the same as a Shapes built up by hand via
+// Builder).
+func ParseWKT(s string) (*Shapes, error) {
+	s = strings.TrimSpace(s)
+	upper := strings.ToUpper(s)
+	result := &Shapes{}
+	switch {
+	case strings.HasPrefix(upper, "MULTIPOLYGON"):
+		body := strings.TrimSpace(s[len("MULTIPOLYGON"):])
+		wrapped := splitGroups(body)
+		if len(wrapped) != 1 {
+			return nil, fmt.Errorf("malformed WKT multipolygon: %q", s)
+		}
+		for _, polyBody := range splitGroups(wrapped[0]) {
+			shapes, err := parseRingList("(" + polyBody + ")")
+			if err != nil {
+				return nil, err
+			}
+			result = result.Include(shapes...)
+		}
+	case strings.HasPrefix(upper, "POLYGON"):
+		body := strings.TrimSpace(s[len("POLYGON"):])
+		shapes, err := parseRingList(body)
+		if err != nil {
+			return nil, err
+		}
+		result = result.Include(shapes...)
+	default:
+		return nil, fmt.Errorf("unsupported WKT geometry: %q", s)
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package polygon
+
+import "testing"
+
+func TestMarshalWKTPolygon(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	got, err := MarshalWKT(ss)
+	if err != nil {
+		t.Fatalf("MarshalWKT: %v", err)
+	}
+	want := "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))"
+	if got != want {
+		t.Errorf("MarshalWKT = %q, want %q", got, want)
+	}
+}
+
+func TestMarshalWKTPolygonWithHole(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	ss = ss.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4}) // clockwise -> hole
+	got, err := MarshalWKT(ss)
+	if err != nil {
+		t.Fatalf("MarshalWKT: %v", err)
+	}
+	want := "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 4 6, 6 6, 6 4, 4 4))"
+	if got != want {
+		t.Errorf("MarshalWKT = %q, want %q", got, want)
+	}
+}
+
+func TestMarshalWKTMultiPolygon(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{1, 1})...)
+	ss = ss.Builder(square(Point{2, 2}, Point{3, 3})...)
+	got, err := MarshalWKT(ss)
+	if err != nil {
+		t.Fatalf("MarshalWKT: %v", err)
+	}
+	want := "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((2 2, 3 2, 3 3, 2 3, 2 2)))"
+	if got != want {
+		t.Errorf("MarshalWKT = %q, want %q", got, want)
+	}
+}
+
+func TestParseWKTPolygonRoundTrip(t *testing.T) {
+	var ss *Shapes
+	ss = ss.Builder(square(Point{0, 0}, Point{10, 10})...)
+	ss = ss.Builder(Point{4, 4}, Point{4, 6}, Point{6, 6}, Point{6, 4})
+	text, err := MarshalWKT(ss)
+	if err != nil {
+		t.Fatalf("MarshalWKT: %v", err)
+	}
+	got, err := ParseWKT(text)
+	if err != nil {
+		t.Fatalf("ParseWKT(%q): %v", text, err)
+	}
+	if len(got.P) != len(ss.P) {
+		t.Fatalf("round trip: got %d shapes, want %d", len(got.P), len(ss.P))
+	}
+	for i, s := range ss.P {
+		checkPoints(t, "round trip", got.P[i].PS, s.PS)
+		if got.P[i].Hole != s.Hole {
+			t.Errorf("round trip shape %d: Hole = %v, want %v", i, got.P[i].Hole, s.Hole)
+		}
+	}
+}
+
+func TestParseWKTMultiPolygon(t *testing.T) {
+	got, err := ParseWKT("MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((2 2, 3 2, 3 3, 2 3, 2 2)))")
+	if err != nil {
+		t.Fatalf("ParseWKT: %v", err)
+	}
+	if len(got.P) != 2 {
+		t.Fatalf("got %d shapes, want 2", len(got.P))
+	}
+	checkPoints(t, "multipolygon[0]", got.P[0].PS, square(Point{0, 0}, Point{1, 1}))
+	checkPoints(t, "multipolygon[1]", got.P[1].PS, square(Point{2, 2}, Point{3, 3}))
+}
+
+func TestParseWKTUnsupported(t *testing.T) {
+	if _, err := ParseWKT("POINT (0 0)"); err == nil {
+		t.Errorf("ParseWKT(POINT): expected an error, got nil")
+	}
+}